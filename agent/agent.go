@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -94,6 +95,8 @@ func (a *Agent) Start() error {
 				a.Delete(cmd.Delete, cmd.OPID, ep)
 			case pbm.CmdDeletePITR:
 				a.DeletePITR(cmd.DeletePITR, cmd.OPID, ep)
+			case pbm.CmdCheckStorage:
+				a.CheckStorage(cmd.OPID, ep)
 			}
 		case err, ok := <-cerr:
 			if !ok {
@@ -239,6 +242,39 @@ func (a *Agent) DeletePITR(d pbm.DeletePITRCmd, opid pbm.OPID, ep pbm.Epoch) {
 	l.Info("done")
 }
 
+// CheckStorage writes, stats and deletes a probe file on the storage
+// and logs the result. Unlike the heartbeat check, it is done on every
+// agent and always writes.
+func (a *Agent) CheckStorage(opid pbm.OPID, ep pbm.Epoch) {
+	l := a.pbm.Logger().NewEvent(string(pbm.CmdCheckStorage), "", opid.String(), ep.TS())
+
+	stg, err := a.pbm.GetStorage(l)
+	if err != nil {
+		l.Error("get storage: %v", err)
+		return
+	}
+
+	probe := fmt.Sprintf(".pbm.probe.%s.%s", a.node.RS(), strings.ReplaceAll(a.node.Name(), ":", "_"))
+	data := []byte(version.DefaultInfo.Version)
+	err = stg.Save(probe, bytes.NewReader(data), len(data))
+	if err != nil {
+		l.Error("write %s: %v", probe, err)
+		return
+	}
+	_, err = stg.FileStat(probe)
+	if err != nil {
+		l.Error("stat %s: %v", probe, err)
+		return
+	}
+	err = stg.Delete(probe)
+	if err != nil {
+		l.Error("delete %s: %v", probe, err)
+		return
+	}
+
+	l.Info("storage is writable")
+}
+
 // Resync uploads a backup list from the remote store
 func (a *Agent) Resync(opid pbm.OPID, ep pbm.Epoch) {
 	l := a.pbm.Logger().NewEvent(string(pbm.CmdResync), "", opid.String(), ep.TS())
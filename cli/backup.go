@@ -2,14 +2,25 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/yaml.v2"
 
 	"github.com/percona/percona-backup-mongodb/pbm"
+	"github.com/percona/percona-backup-mongodb/pbm/storage"
 )
 
 type backupOpts struct {
@@ -17,25 +28,80 @@ type backupOpts struct {
 	typ              string
 	compression      string
 	compressionLevel []int
+	parallelColls    int
+	compressThreads  int
+	labels           map[string]string
+	labelsFile       string
+	onSuccess        string
+	maxUploadMbps    int
+	uploadRetries    int
+	wait             bool
+	dryRun           bool
+	metaOut          string
 }
 
+// maxParallelColls is the upper limit for the number of collections
+// dumped in parallel
+const maxParallelColls = 100
+
 type backupOut struct {
-	Name    string `json:"name"`
-	Storage string `json:"storage"`
+	Name    string     `json:"name"`
+	Storage string     `json:"storage"`
+	Status  pbm.Status `json:"status,omitempty"`
+	Error   string     `json:"error,omitempty"`
+}
+
+func (b backupOut) HasError() bool {
+	return b.Error != ""
 }
 
 func (b backupOut) String() string {
-	return fmt.Sprintf("Backup '%s' to remote store '%s' has started", b.Name, b.Storage)
+	switch {
+	case b.Error != "":
+		return "\n Error: " + b.Error
+	case b.Status == pbm.StatusDone:
+		return fmt.Sprintf("\nBackup '%s' to remote store '%s' successfully finished!", b.Name, b.Storage)
+	default:
+		return fmt.Sprintf("Backup '%s' to remote store '%s' has started", b.Name, b.Storage)
+	}
 }
 
-func runBackup(cn *pbm.PBM, b *backupOpts, outf outFormat) (fmt.Stringer, error) {
-	err := checkConcurrentOp(cn)
-	if err != nil {
-		// PITR slicing can be run along with the backup start - agents will resolve it.
-		op, ok := err.(concurentOpErr)
-		if !ok || op.op.Type != pbm.CmdPITR {
-			return nil, err
-		}
+type backupPlan struct {
+	Name             string `json:"name"`
+	Type             string `json:"type"`
+	Storage          string `json:"storage"`
+	Compression      string `json:"compression"`
+	CompressionLevel *int   `json:"compressionLevel,omitempty"`
+	DataSize         int64  `json:"dataSize,omitempty"`
+	EstimatedSize    int64  `json:"estimatedSize,omitempty"`
+	EstimateErr      string `json:"estimateError,omitempty"`
+}
+
+func (p backupPlan) String() string {
+	s := "Dry run. Backup would be made with:\n"
+	s += fmt.Sprintf("  name:        %s\n", p.Name)
+	s += fmt.Sprintf("  type:        %s\n", p.Type)
+	s += fmt.Sprintf("  storage:     %s\n", p.Storage)
+	s += fmt.Sprintf("  compression: %s", p.Compression)
+	if p.CompressionLevel != nil {
+		s += fmt.Sprintf(" (level: %d)", *p.CompressionLevel)
+	}
+	s += "\n"
+	if p.EstimateErr != "" {
+		s += fmt.Sprintf("  size:        unknown (%s)\n", p.EstimateErr)
+	} else {
+		s += fmt.Sprintf("  size:        ~%s (data: %s)\n", fmtSize(p.EstimatedSize), fmtSize(p.DataSize))
+	}
+
+	return s + "All checks passed, no backup has been started"
+}
+
+func runBackup(cn *pbm.PBM, b *backupOpts, curi string, outf outFormat) (fmt.Stringer, error) {
+	if b.metaOut != "" && !b.wait {
+		return nil, errors.New("--metadata-out requires --wait")
+	}
+	if b.onSuccess != "" && !b.wait {
+		return nil, errors.New("--on-success requires --wait")
 	}
 
 	cfg, err := cn.GetConfig()
@@ -46,29 +112,131 @@ func runBackup(cn *pbm.PBM, b *backupOpts, outf outFormat) (fmt.Stringer, error)
 		return nil, errors.Wrap(err, "get remote-store")
 	}
 
+	// defaults from the config are applied only to unset flags
+	cfgCompression := cfg.Backup.Compression
+	if cfgCompression == "" {
+		cfgCompression = pbm.CompressionTypeS2
+	}
 	var level *int
+	if b.compression == "" || pbm.CompressionType(b.compression) == cfgCompression {
+		level = cfg.Backup.CompressionLevel
+	}
+	if b.compression == "" {
+		b.compression = string(cfgCompression)
+	}
+
 	if len(b.compressionLevel) > 0 {
 		level = &b.compressionLevel[0]
 	}
+	if level != nil {
+		err := pbm.CheckCompressionLevel(pbm.CompressionType(b.compression), *level)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if b.parallelColls != 0 {
+		if b.typ != string(pbm.LogicalBackup) {
+			return nil, errors.New("--num-parallel-collections is applicable to logical backups only")
+		}
+		if b.parallelColls < 0 || b.parallelColls > maxParallelColls {
+			return nil, errors.Errorf("--num-parallel-collections should be in range [1, %d]", maxParallelColls)
+		}
+	}
+
+	if b.compressThreads != 0 {
+		switch pbm.CompressionType(b.compression) {
+		case pbm.CompressionTypePGZIP, pbm.CompressionTypeS2, pbm.CompressionTypeZstandard:
+		default:
+			return nil, errors.Errorf("--compress-threads is not supported by the %s compression", b.compression)
+		}
+		if b.compressThreads < 0 {
+			return nil, errors.New("--compress-threads should be a positive number")
+		}
+	}
+
+	if b.maxUploadMbps < 0 {
+		return nil, errors.New("--max-upload-mbps can't be negative")
+	}
+
+	if b.uploadRetries != 0 {
+		if b.typ != string(pbm.PhysicalBackup) {
+			return nil, errors.New("--upload-retries is applicable to physical backups only")
+		}
+		if b.uploadRetries < 0 {
+			return nil, errors.New("--upload-retries can't be negative")
+		}
+	}
+
+	if b.labelsFile != "" {
+		labels, err := readLabels(b.labelsFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "--tags-from-file")
+		}
+		// --label overrides the file
+		for k, v := range b.labels {
+			labels[k] = v
+		}
+		b.labels = labels
+	}
+	for k := range b.labels {
+		if strings.TrimSpace(k) == "" {
+			return nil, errors.New("--label key can't be empty")
+		}
+	}
+
+	err = checkConcurrentOp(cn)
+	if err != nil {
+		// PITR slicing can be run along with the backup start - agents will resolve it.
+		op, ok := err.(concurentOpErr)
+		if !ok || op.op.Type != pbm.CmdPITR {
+			return nil, err
+		}
+	}
+
+	err = checkAgentsStorage(cn, &cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.dryRun {
+		plan := backupPlan{
+			Name:             b.name,
+			Type:             b.typ,
+			Storage:          fmt.Sprintf("%s %s", cfg.Storage.Typ(), cfg.Storage.Path()),
+			Compression:      b.compression,
+			CompressionLevel: level,
+		}
+		plan.DataSize, plan.EstimatedSize, err = estimateBackupSize(cn, curi, b)
+		if err != nil {
+			plan.EstimateErr = err.Error()
+		}
+		return plan, nil
+	}
 
 	err = cn.SendCmd(pbm.Cmd{
 		Cmd: pbm.CmdBackup,
 		Backup: pbm.BackupCmd{
-			Type:             pbm.BackupType(b.typ),
-			Name:             b.name,
-			Compression:      pbm.CompressionType(b.compression),
-			CompressionLevel: level,
+			Type:                   pbm.BackupType(b.typ),
+			Name:                   b.name,
+			Compression:            pbm.CompressionType(b.compression),
+			CompressionLevel:       level,
+			NumParallelCollections: b.parallelColls,
+			CompressionThreads:     b.compressThreads,
+			Labels:                 b.labels,
+			MaxUploadMbps:          b.maxUploadMbps,
+			UploadRetries:          b.uploadRetries,
 		},
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "send command")
 	}
 
-	if outf != outText {
-		return backupOut{b.name, cfg.Storage.Path()}, nil
+	if outf != outText && !b.wait {
+		return backupOut{Name: b.name, Storage: cfg.Storage.Path()}, nil
 	}
 
-	fmt.Printf("Starting backup '%s'", b.name)
+	fmt.Fprintf(progw, "Starting backup '%s'", b.name)
 	ctx, cancel := context.WithTimeout(context.Background(), pbm.WaitBackupStart)
 	defer cancel()
 	err = waitForBcpStatus(ctx, cn, b.name)
@@ -76,8 +244,270 @@ func runBackup(cn *pbm.PBM, b *backupOpts, outf outFormat) (fmt.Stringer, error)
 		return nil, err
 	}
 
-	fmt.Println()
-	return backupOut{b.name, cfg.Storage.Path()}, nil
+	if !b.wait {
+		fmt.Fprintln(progw)
+		return backupOut{Name: b.name, Storage: cfg.Storage.Path()}, nil
+	}
+
+	fmt.Fprint(progw, "\nWaiting to finish")
+	out := backupOut{Name: b.name, Storage: cfg.Storage.Path(), Status: pbm.StatusDone}
+	err = waitBackup(cn, b.name)
+	if err != nil {
+		out.Status = pbm.StatusError
+		out.Error = err.Error()
+		return out, nil
+	}
+
+	if b.metaOut != "" {
+		err = writeBackupMeta(cn, b.name, b.metaOut)
+		if err != nil {
+			out.Error = "backup finished but " + err.Error()
+			return out, nil
+		}
+	}
+
+	if b.onSuccess != "" {
+		// keep the structured output parsable
+		hookOut := io.Writer(os.Stdout)
+		if outf != outText {
+			hookOut = os.Stderr
+		}
+		err = runHook(b.onSuccess, b.name, cfg.Storage.Path(), b.metaOut, hookOut)
+		if err != nil {
+			out.Error = "backup finished but " + err.Error()
+			return out, nil
+		}
+	}
+
+	return out, nil
+}
+
+// runHook runs the shell command after the successful backup. The backup
+// name, its storage and metadata file names are passed via env variables.
+// PBM_BACKUP_METADATA is the --metadata-out file if set, otherwise
+// the metadata file name on the storage.
+func runHook(command, name, stg, metaOut string, stdout io.Writer) error {
+	meta := metaOut
+	if meta == "" {
+		meta = name + pbm.MetadataFileSuffix
+	}
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"PBM_BACKUP_NAME="+name,
+		"PBM_BACKUP_STORAGE="+stg,
+		"PBM_BACKUP_METADATA="+meta,
+	)
+
+	err := cmd.Run()
+	return errors.Wrap(err, "on-success hook failed")
+}
+
+// writeBackupMeta writes the backup metadata in JSON to the given file
+func writeBackupMeta(cn *pbm.PBM, name, fname string) error {
+	bmeta, err := cn.GetBackupMeta(name)
+	if err != nil {
+		return errors.Wrap(err, "get backup metadata")
+	}
+
+	f, err := os.Create(fname)
+	if err != nil {
+		return errors.Wrap(err, "create metadata file")
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(bmeta)
+	if err != nil {
+		return errors.Wrap(err, "write metadata file")
+	}
+
+	return f.Close()
+}
+
+// waitBackup waits for the backup to finish either successfully or with an error
+func waitBackup(cn *pbm.PBM, name string) error {
+	tk := time.NewTicker(time.Second * 1)
+	defer tk.Stop()
+
+	for range tk.C {
+		fmt.Fprint(progw, ".")
+		bmeta, err := cn.GetBackupMeta(name)
+		if err != nil {
+			return errors.Wrap(err, "get backup metadata")
+		}
+
+		switch bmeta.Status {
+		case pbm.StatusDone:
+			return nil
+		case pbm.StatusError, pbm.StatusCancelled:
+			return errors.New(bcpErrDetails(bmeta))
+		}
+
+		clusterTime, err := cn.ClusterTime()
+		if err != nil {
+			return errors.Wrap(err, "read cluster time")
+		}
+		if bmeta.Hb.T+pbm.StaleFrameSec < clusterTime.T {
+			return errors.Errorf("operation staled, last heartbeat: %v", bmeta.Hb.T)
+		}
+	}
+
+	return nil
+}
+
+func bcpErrDetails(bmeta *pbm.BackupMeta) string {
+	s := bmeta.Error
+	if bmeta.Status == pbm.StatusCancelled {
+		s = "backup was cancelled"
+	}
+	for _, rs := range bmeta.Replsets {
+		s += fmt.Sprintf("\n- Backup on replicaset \"%s\" in state: %v", rs.Name, rs.Status)
+		if rs.Error != "" {
+			s += ": " + rs.Error
+		}
+	}
+
+	return s
+}
+
+// readLabels reads backup labels from the YAML file with the key: value map
+func readLabels(fname string) (map[string]string, error) {
+	fname, err := expandPath(fname)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := ioutil.ReadFile(fname)
+	if err != nil {
+		return nil, errors.Wrap(err, "read file")
+	}
+
+	labels := make(map[string]string)
+	err = yaml.UnmarshalStrict(buf, &labels)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", fname)
+	}
+
+	return labels, nil
+}
+
+// estimateBackupSize returns the uncompressed data size of the cluster
+// and a rough size of the backup. Logical backups don't contain indexes and
+// their compression ratio is assumed to be close to the WiredTiger one,
+// physical backups copy data files as is.
+func estimateBackupSize(cn *pbm.PBM, curi string, b *backupOpts) (data, estimated int64, err error) {
+	shards, err := cn.ClusterMembers()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "get cluster members")
+	}
+
+	for _, sh := range shards {
+		conn, err := connect(cn.Context(), curi, sh.Host)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "connect to `%s` [%s]", sh.RS, sh.Host)
+		}
+		st, err := dbsStats(cn.Context(), conn)
+		conn.Disconnect(cn.Context())
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "get `%s` stats", sh.RS)
+		}
+
+		data += int64(st.DataSize)
+		switch {
+		case b.typ == string(pbm.PhysicalBackup):
+			estimated += int64(st.StorageSize + st.IndexSize)
+		case b.compression == string(pbm.CompressionTypeNone):
+			estimated += int64(st.DataSize)
+		default:
+			estimated += int64(st.StorageSize)
+		}
+	}
+
+	return data, estimated, nil
+}
+
+// dbStats sizes are in bytes. They are doubles as mongo may report
+// them as doubles
+type dbStats struct {
+	DataSize    float64 `bson:"dataSize"`
+	StorageSize float64 `bson:"storageSize"`
+	IndexSize   float64 `bson:"indexSize"`
+}
+
+// dbsStats sums up stats of all databases but `local`
+func dbsStats(ctx context.Context, conn *mongo.Client) (dbStats, error) {
+	var total dbStats
+
+	dbs, err := conn.ListDatabaseNames(ctx, bson.D{{"name", bson.M{"$ne": "local"}}})
+	if err != nil {
+		return total, errors.Wrap(err, "list databases")
+	}
+	for _, db := range dbs {
+		var st dbStats
+		err := conn.Database(db).RunCommand(ctx, bson.D{{"dbStats", 1}}).Decode(&st)
+		if err != nil {
+			return total, errors.Wrapf(err, "run dbStats for %s", db)
+		}
+		total.DataSize += st.DataSize
+		total.StorageSize += st.StorageSize
+		total.IndexSize += st.IndexSize
+	}
+
+	return total, nil
+}
+
+// checkAgentsStorage checks that each replset has at least one live agent
+// reporting the remote storage as available. Otherwise, the backup
+// would fail on that replset after it has already started on the rest.
+func checkAgentsStorage(cn *pbm.PBM, stg *pbm.StorageConf) error {
+	agents, err := cn.AgentsStatus()
+	if err != nil {
+		return errors.Wrap(err, "get agents status")
+	}
+
+	shards, err := cn.ClusterMembers()
+	if err != nil {
+		return errors.Wrap(err, "get cluster members")
+	}
+	ts, err := cn.ClusterTime()
+	if err != nil {
+		return errors.Wrap(err, "read cluster time")
+	}
+
+	// the status of dead agents is out of date
+	ok := make(map[string]bool)
+	failed := make(map[string][]string)
+	for _, a := range agents {
+		if a.Heartbeat.T+pbm.StaleFrameSec < ts.T {
+			continue
+		}
+		if a.StorageStatus.OK {
+			ok[a.RS] = true
+			continue
+		}
+		failed[a.RS] = append(failed[a.RS], fmt.Sprintf("agent %s/%s does not have access to %s storage: %s", a.RS, a.Node, stg.Typ(), a.StorageStatus.Err))
+	}
+
+	var errs []string
+	for _, sh := range shards {
+		if ok[sh.RS] {
+			continue
+		}
+		if e, is := failed[sh.RS]; is {
+			errs = append(errs, e...)
+		} else {
+			errs = append(errs, fmt.Sprintf("replset %s has no live agents", sh.RS))
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.Errorf("no agent on some replsets can access the storage:\n%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
 }
 
 func waitForBcpStatus(ctx context.Context, cn *pbm.PBM, bcpName string) (err error) {
@@ -88,7 +518,7 @@ func waitForBcpStatus(ctx context.Context, cn *pbm.PBM, bcpName string) (err err
 	for {
 		select {
 		case <-tk.C:
-			fmt.Print(".")
+			fmt.Fprint(progw, ".")
 			bmeta, err = cn.GetBackupMeta(bcpName)
 			if errors.Is(err, pbm.ErrNotFound) {
 				continue
@@ -100,14 +530,7 @@ func waitForBcpStatus(ctx context.Context, cn *pbm.PBM, bcpName string) (err err
 			case pbm.StatusRunning, pbm.StatusDumpDone, pbm.StatusDone:
 				return nil
 			case pbm.StatusError:
-				rs := ""
-				for _, s := range bmeta.Replsets {
-					rs += fmt.Sprintf("\n- Backup on replicaset \"%s\" in state: %v", s.Name, s.Status)
-					if s.Error != "" {
-						rs += ": " + s.Error
-					}
-				}
-				return errors.New(bmeta.Error + rs)
+				return errors.New(bcpErrDetails(bmeta))
 			}
 		case <-ctx.Done():
 			if bmeta == nil {
@@ -182,3 +605,336 @@ func bcpMatchCluster(bcp *pbm.BackupMeta, shards map[string]struct{}, confsrv st
 		bcp.Status = pbm.StatusError
 	}
 }
+
+type validateRSOut struct {
+	Name   string           `json:"name"`
+	Checks []preflightCheck `json:"checks"`
+}
+
+type validateOut struct {
+	Backup   string           `json:"backup"`
+	Type     pbm.BackupType   `json:"type,omitempty"`
+	Checks   []preflightCheck `json:"checks"`
+	Replsets []validateRSOut  `json:"replsets,omitempty"`
+}
+
+func (v validateOut) HasError() bool {
+	for _, c := range v.Checks {
+		if !c.OK {
+			return true
+		}
+	}
+	for _, rs := range v.Replsets {
+		for _, c := range rs.Checks {
+			if !c.OK {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (v validateOut) String() string {
+	s := fmt.Sprintf("Validation of the backup '%s':\n", v.Backup)
+	fmtCheck := func(ident string, c preflightCheck) string {
+		if c.OK {
+			return fmt.Sprintf("%s[OK]     %s\n", ident, c.Name)
+		}
+		return fmt.Sprintf("%s[FAILED] %s: %s\n", ident, c.Name, c.Error)
+	}
+	for _, c := range v.Checks {
+		s += fmtCheck("  ", c)
+	}
+	for _, rs := range v.Replsets {
+		s += fmt.Sprintf("  %s:\n", rs.Name)
+		for _, c := range rs.Checks {
+			s += fmtCheck("    ", c)
+		}
+	}
+
+	if v.HasError() {
+		return s + "\nValidation failed"
+	}
+	return s + "\nValidation passed"
+}
+
+func addCheck(checks *[]preflightCheck, name string, err error) bool {
+	c := preflightCheck{Name: name, OK: err == nil}
+	if err != nil {
+		c.Error = err.Error()
+	}
+	*checks = append(*checks, c)
+
+	return c.OK
+}
+
+// bcpNotFoundErr is returned when there is no backup with the given name.
+// It suggests the existing backups with similar names.
+type bcpNotFoundErr struct {
+	name    string
+	similar []string
+}
+
+func newBcpNotFoundErr(cn *pbm.PBM, name string) bcpNotFoundErr {
+	e := bcpNotFoundErr{name: name}
+
+	bcps, err := cn.BackupsList(0)
+	if err != nil {
+		return e
+	}
+	names := make([]string, 0, len(bcps))
+	for _, b := range bcps {
+		names = append(names, b.Name)
+	}
+	e.similar = closeNames(name, names)
+
+	return e
+}
+
+func (e bcpNotFoundErr) Error() string {
+	s := fmt.Sprintf("backup '%s' not found", e.name)
+	if len(e.similar) > 0 {
+		s += fmt.Sprintf(". Did you mean: %s?", strings.Join(e.similar, ", "))
+	}
+	return s
+}
+
+func (bcpNotFoundErr) Unwrap() error {
+	return pbm.ErrNotFound
+}
+
+// validateBackup checks that the backup artifacts are present on the storage
+// and match the backup metadata. There are no checksums in the metadata so
+// files are verified by their presence and size.
+func validateBackup(cn *pbm.PBM, name string) (fmt.Stringer, error) {
+	out := validateOut{Backup: name}
+
+	bcp, err := cn.GetBackupMeta(name)
+	if errors.Is(err, pbm.ErrNotFound) {
+		err = newBcpNotFoundErr(cn, name)
+	}
+	if !addCheck(&out.Checks, "backup metadata", err) {
+		return out, nil
+	}
+	out.Type = bcp.Type
+
+	err = nil
+	if bcp.Status != pbm.StatusDone {
+		err = errors.Errorf("backup didn't finish successfully, status: %s", bcp.Status)
+	}
+	addCheck(&out.Checks, "backup status", err)
+
+	stg, err := cn.GetStorage(cn.Logger().NewEvent("", "", "", primitive.Timestamp{}))
+	if !addCheck(&out.Checks, "storage access", errors.Wrap(err, "get storage")) {
+		return out, nil
+	}
+
+	for _, rs := range bcp.Replsets {
+		rout := validateRSOut{Name: rs.Name}
+		addCheck(&rout.Checks, "backup files", checkRSFiles(bcp, rs, stg))
+		if bcp.Type != pbm.PhysicalBackup {
+			addCheck(&rout.Checks, "oplog range", checkRSOplog(bcp, rs))
+		}
+		out.Replsets = append(out.Replsets, rout)
+	}
+
+	return out, nil
+}
+
+// checkRSFiles checks that files of the replset are on the storage and,
+// for physical backups, have the size stored in the backup metadata
+func checkRSFiles(bcp *pbm.BackupMeta, rs pbm.BackupReplset, stg storage.Storage) error {
+	sizes := make(map[string]int64)
+	if bcp.Type == pbm.PhysicalBackup {
+		for _, f := range rs.Files {
+			sizes[filepath.Join(bcp.Name, rs.Name, f.Name+bcp.Compression.Suffix())] = f.StgSize
+		}
+	}
+
+	var bad []string
+	for _, f := range bcpRSFiles(bcp, rs) {
+		fi, err := stg.FileStat(f)
+		if err == storage.ErrNotExist {
+			bad = append(bad, f+" (missing)")
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "get file %s", f)
+		}
+		if sz := sizes[f]; sz > 0 && fi.Size != sz {
+			bad = append(bad, fmt.Sprintf("%s (size %d, expected %d)", f, fi.Size, sz))
+		}
+	}
+	if len(bad) > 0 {
+		return errors.Errorf("invalid files: %s", strings.Join(bad, ", "))
+	}
+
+	return nil
+}
+
+// checkRSOplog checks that the replset's oplog slice is set and that it
+// reaches the cluster-wide backup time. Each replset starts the oplog at its
+// own time, so the start is checked only against the replset's own span.
+func checkRSOplog(bcp *pbm.BackupMeta, rs pbm.BackupReplset) error {
+	// timestamps are initialised with {1,1}, see backup.Init
+	if rs.FirstWriteTS.T <= 1 || rs.LastWriteTS.T <= 1 {
+		return errors.New("oplog range is not set")
+	}
+	if bcp.LastWriteTS.T <= 1 {
+		return errors.New("backup last write is not set")
+	}
+	if primitive.CompareTimestamp(rs.FirstWriteTS, rs.LastWriteTS) == 1 {
+		return errors.Errorf("first write %v is after the last write %v", rs.FirstWriteTS, rs.LastWriteTS)
+	}
+	// the oplog is dumped up to the cluster last write, which is the max
+	// of the replsets' last writes
+	if primitive.CompareTimestamp(rs.LastWriteTS, bcp.LastWriteTS) == 1 {
+		return errors.Errorf("last write %v is after the backup last write %v", rs.LastWriteTS, bcp.LastWriteTS)
+	}
+
+	return nil
+}
+
+type describeRSOut struct {
+	Name       string              `json:"name"`
+	Status     pbm.Status          `json:"status"`
+	Error      string              `json:"error,omitempty"`
+	StartTS    int64               `json:"start_ts"`
+	LastTS     int64               `json:"last_transition_ts"`
+	FirstWrite primitive.Timestamp `json:"first_write_ts"`
+	LastWrite  primitive.Timestamp `json:"last_write_ts"`
+	Files      []string            `json:"files"`
+	Size       int64               `json:"size,omitempty"`
+}
+
+type describeBcpOut struct {
+	Name         string              `json:"name"`
+	OPID         string              `json:"opid"`
+	Type         pbm.BackupType      `json:"type"`
+	Status       pbm.Status          `json:"status"`
+	Error        string              `json:"error,omitempty"`
+	Compression  pbm.CompressionType `json:"compression"`
+	Storage      string              `json:"storage"`
+	MongoVersion string              `json:"mongodb_version,omitempty"`
+	PBMVersion   string              `json:"pbm_version,omitempty"`
+	StartTS      int64               `json:"start_ts"`
+	LastTS       int64               `json:"last_transition_ts"`
+	FirstWrite   primitive.Timestamp `json:"first_write_ts"`
+	LastWrite    primitive.Timestamp `json:"last_write_ts"`
+	Labels       map[string]string   `json:"labels,omitempty"`
+	Size         int64               `json:"size,omitempty"`
+	SizeErr      string              `json:"size_error,omitempty"`
+	Replsets     []describeRSOut     `json:"replsets"`
+}
+
+func (d describeBcpOut) String() string {
+	s := fmt.Sprintf("Name:         %s\n", d.Name)
+	s += fmt.Sprintf("OPID:         %s\n", d.OPID)
+	s += fmt.Sprintf("Type:         %s\n", d.Type)
+	s += fmt.Sprintf("Status:       %s\n", d.Status)
+	if d.Error != "" {
+		s += fmt.Sprintf("Error:        %s\n", d.Error)
+	}
+	s += fmt.Sprintf("Compression:  %s\n", d.Compression)
+	s += fmt.Sprintf("Storage:      %s\n", d.Storage)
+	if d.SizeErr != "" {
+		s += fmt.Sprintf("Size:         unknown (%s)\n", d.SizeErr)
+	} else {
+		s += fmt.Sprintf("Size:         %s\n", fmtSize(d.Size))
+	}
+	s += fmt.Sprintf("MongoDB:      %s\n", d.MongoVersion)
+	s += fmt.Sprintf("PBM:          %s\n", d.PBMVersion)
+	s += fmt.Sprintf("Started:      %s\n", fmtTS(d.StartTS))
+	s += fmt.Sprintf("Updated:      %s\n", fmtTS(d.LastTS))
+	s += fmt.Sprintf("Oplog:        %s - %s\n", fmtTS(int64(d.FirstWrite.T)), fmtTS(int64(d.LastWrite.T)))
+	if len(d.Labels) > 0 {
+		lbs := make([]string, 0, len(d.Labels))
+		for k, v := range d.Labels {
+			lbs = append(lbs, k+"="+v)
+		}
+		sort.Strings(lbs)
+		s += fmt.Sprintf("Labels:       %s\n", strings.Join(lbs, ", "))
+	}
+
+	s += "Replsets:\n"
+	for _, rs := range d.Replsets {
+		s += fmt.Sprintf("  %s:\n", rs.Name)
+		s += fmt.Sprintf("    status:  %s\n", rs.Status)
+		if rs.Error != "" {
+			s += fmt.Sprintf("    error:   %s\n", rs.Error)
+		}
+		s += fmt.Sprintf("    started: %s, updated: %s\n", fmtTS(rs.StartTS), fmtTS(rs.LastTS))
+		s += fmt.Sprintf("    oplog:   %s - %s\n", fmtTS(int64(rs.FirstWrite.T)), fmtTS(int64(rs.LastWrite.T)))
+		if rs.Size > 0 {
+			s += fmt.Sprintf("    size:    %s\n", fmtSize(rs.Size))
+		}
+		s += "    files:\n"
+		for _, f := range rs.Files {
+			s += fmt.Sprintf("      %s\n", f)
+		}
+	}
+
+	return s
+}
+
+func describeBackup(cn *pbm.PBM, name string) (fmt.Stringer, error) {
+	bcp, err := cn.GetBackupMeta(name)
+	if errors.Is(err, pbm.ErrNotFound) {
+		return nil, newBcpNotFoundErr(cn, name)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "get backup meta")
+	}
+
+	out := describeBcpOut{
+		Name:         bcp.Name,
+		OPID:         bcp.OPID,
+		Type:         bcp.Type,
+		Status:       bcp.Status,
+		Error:        bcp.Error,
+		Compression:  bcp.Compression,
+		Storage:      fmt.Sprintf("%s %s", bcp.Store.Typ(), bcp.Store.Path()),
+		MongoVersion: bcp.MongoVersion,
+		PBMVersion:   bcp.PBMVersion,
+		StartTS:      bcp.StartTS,
+		LastTS:       bcp.LastTransitionTS,
+		FirstWrite:   bcp.FirstWriteTS,
+		LastWrite:    bcp.LastWriteTS,
+		Labels:       bcp.Labels,
+	}
+
+	stg, err := cn.GetStorage(cn.Logger().NewEvent("", "", "", primitive.Timestamp{}))
+	if err != nil {
+		out.SizeErr = errors.Wrap(err, "get storage").Error()
+	}
+
+	for _, rs := range bcp.Replsets {
+		rout := describeRSOut{
+			Name:       rs.Name,
+			Status:     rs.Status,
+			Error:      rs.Error,
+			StartTS:    rs.StartTS,
+			LastTS:     rs.LastTransitionTS,
+			FirstWrite: rs.FirstWriteTS,
+			LastWrite:  rs.LastWriteTS,
+			Files:      bcpRSFiles(bcp, rs),
+		}
+
+		switch {
+		case bcp.Type == pbm.PhysicalBackup:
+			for _, f := range rs.Files {
+				rout.Size += f.StgSize
+			}
+		case stg != nil:
+			rout.Size, err = getSnapshotSize([]pbm.BackupReplset{rs}, stg)
+			if err != nil && out.SizeErr == "" {
+				out.SizeErr = err.Error()
+			}
+		}
+		out.Size += rout.Size
+		out.Replsets = append(out.Replsets, rout)
+	}
+
+	return out, nil
+}
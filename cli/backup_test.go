@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
 	"github.com/percona/percona-backup-mongodb/pbm"
 )
 
@@ -395,3 +397,35 @@ func BenchmarkBcpMatchCluster1000x1000Err(b *testing.B) {
 		bcpsMatchCluster(bcps, shards, "config", identityStr)
 	}
 }
+
+func TestCheckRSOplog(t *testing.T) {
+	ts := func(sec uint32) primitive.Timestamp { return primitive.Timestamp{T: sec, I: 1} }
+
+	// FirstWriteTS of the backup is the min of the replsets' ones
+	// and LastWriteTS is the max, see backup.setClusterFirstWrite
+	bcp := &pbm.BackupMeta{
+		FirstWriteTS: ts(100),
+		LastWriteTS:  ts(200),
+	}
+	cases := []struct {
+		rs  pbm.BackupReplset
+		err bool
+	}{
+		{pbm.BackupReplset{Name: "rs1", FirstWriteTS: ts(100), LastWriteTS: ts(200)}, false},
+		{pbm.BackupReplset{Name: "rs2", FirstWriteTS: ts(150), LastWriteTS: ts(180)}, false},
+		{pbm.BackupReplset{Name: "rs3", FirstWriteTS: ts(1), LastWriteTS: ts(180)}, true},
+		{pbm.BackupReplset{Name: "rs4", FirstWriteTS: ts(190), LastWriteTS: ts(180)}, true},
+		{pbm.BackupReplset{Name: "rs5", FirstWriteTS: ts(150), LastWriteTS: ts(210)}, true},
+	}
+	for _, c := range cases {
+		err := checkRSOplog(bcp, c.rs)
+		if (err != nil) != c.err {
+			t.Errorf("%s: expected error %v, got %v", c.rs.Name, c.err, err)
+		}
+	}
+
+	err := checkRSOplog(&pbm.BackupMeta{FirstWriteTS: ts(100), LastWriteTS: ts(1)}, cases[0].rs)
+	if err == nil {
+		t.Error("expected error for unset backup last write")
+	}
+}
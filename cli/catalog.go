@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/percona/percona-backup-mongodb/pbm"
+	"github.com/percona/percona-backup-mongodb/pbm/backup"
+	"github.com/percona/percona-backup-mongodb/pbm/storage/azure"
+	"github.com/percona/percona-backup-mongodb/pbm/storage/s3"
+	"github.com/percona/percona-backup-mongodb/version"
+)
+
+type catalogOpts struct {
+	file      string
+	overwrite bool
+}
+
+type catalogOut struct {
+	File     string   `json:"file"`
+	Exported []string `json:"exported,omitempty"`
+	Imported []string `json:"imported,omitempty"`
+}
+
+func (c catalogOut) String() string {
+	if c.Exported != nil {
+		return fmt.Sprintf("Exported %d backups to %s\n", len(c.Exported), c.File)
+	}
+	return fmt.Sprintf("Imported %d backups from %s\n", len(c.Imported), c.File)
+}
+
+// catalogDoc is the document written by `catalog export`
+type catalogDoc struct {
+	PBMVersion string           `json:"pbm_version"`
+	Backups    []pbm.BackupMeta `json:"backups"`
+}
+
+func exportCatalog(cn *pbm.PBM, c *catalogOpts) (fmt.Stringer, error) {
+	list, err := cn.BackupsList(0)
+	if err != nil {
+		return nil, errors.Wrap(err, "get backups list")
+	}
+
+	// unfinished and failed backups can't be restored and their files
+	// are incomplete, so there is no use in keeping them
+	var bcps []pbm.BackupMeta
+	for _, b := range list {
+		if b.Status != pbm.StatusDone {
+			continue
+		}
+		// the file is stored outside the cluster, keep no secrets in it
+		b.Store.S3.Credentials = s3.Credentials{}
+		b.Store.Azure.Credentials = azure.Credentials{}
+		bcps = append(bcps, b)
+	}
+
+	// the file may be the only copy of the catalog, so don't overwrite it
+	f, err := os.OpenFile(c.file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "create file")
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	err = enc.Encode(catalogDoc{PBMVersion: version.DefaultInfo.Version, Backups: bcps})
+	if err != nil {
+		return nil, errors.Wrap(err, "write catalog")
+	}
+	err = f.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "close file")
+	}
+
+	out := catalogOut{File: c.file, Exported: []string{}}
+	for _, b := range bcps {
+		out.Exported = append(out.Exported, b.Name)
+	}
+	return out, nil
+}
+
+func importCatalog(cn *pbm.PBM, c *catalogOpts) (fmt.Stringer, error) {
+	f, err := os.Open(c.file)
+	if err != nil {
+		return nil, errors.Wrap(err, "open file")
+	}
+	defer f.Close()
+
+	var cat catalogDoc
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	err = dec.Decode(&cat)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode catalog")
+	}
+
+	// check everything before the first write so that a failed import
+	// doesn't leave the catalog half-updated
+	var dups []string
+	for _, b := range cat.Backups {
+		if b.Name == "" {
+			return nil, errors.New("catalog has a backup without a name")
+		}
+		_, err := cn.GetBackupMeta(b.Name)
+		if err == nil {
+			dups = append(dups, b.Name)
+			continue
+		}
+		if !errors.Is(err, pbm.ErrNotFound) {
+			return nil, errors.Wrapf(err, "get backup %s", b.Name)
+		}
+	}
+	if len(dups) > 0 && !c.overwrite {
+		return nil, errors.Errorf("backups already exist: %s. Use --overwrite to replace them", strings.Join(dups, ", "))
+	}
+
+	// imported backups have to be on the current storage, otherwise
+	// they can't be restored and the next resync would drop them
+	cfg, err := cn.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "get config")
+	}
+	stg, err := cn.GetStorage(cn.Logger().NewEvent("", "", "", primitive.Timestamp{}))
+	if err != nil {
+		return nil, errors.Wrap(err, "get storage")
+	}
+	var errs []string
+	for _, b := range cat.Backups {
+		if b.Store.Typ() != cfg.Storage.Typ() || b.Store.Path() != cfg.Storage.Path() {
+			errs = append(errs, fmt.Sprintf("%s: made on %s %s, but the current storage is %s %s",
+				b.Name, b.Store.Typ(), b.Store.Path(), cfg.Storage.Typ(), cfg.Storage.Path()))
+			continue
+		}
+		if b.Status != pbm.StatusDone {
+			continue
+		}
+		for _, rs := range b.Replsets {
+			err := checkRSFiles(&b, rs, stg)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: replset %s: %v", b.Name, rs.Name, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Errorf("backups don't match the storage:\n%s", strings.Join(errs, "\n"))
+	}
+
+	out := catalogOut{File: c.file, Imported: []string{}}
+	for i := range cat.Backups {
+		b := &cat.Backups[i]
+		// credentials are blanked on export, so take the current ones
+		b.Store = cfg.Storage
+
+		err := backup.WriteMeta(stg, b)
+		if err != nil {
+			return out, errors.Wrapf(err, "write backup %s metadata to the storage", b.Name)
+		}
+		err = cn.ReplaceBackupMeta(b)
+		if err != nil {
+			return out, errors.Wrapf(err, "import backup %s", b.Name)
+		}
+		out.Imported = append(out.Imported, b.Name)
+	}
+
+	return out, nil
+}
@@ -1,17 +1,24 @@
 package cli
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/alecthomas/kingpin"
 	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"gopkg.in/yaml.v2"
 
 	"github.com/percona/percona-backup-mongodb/pbm"
 	plog "github.com/percona/percona-backup-mongodb/pbm/log"
@@ -34,6 +41,7 @@ type outFormat string
 const (
 	outJSON       outFormat = "json"
 	outJSONpretty outFormat = "json-pretty"
+	outYAML       outFormat = "yaml"
 	outText       outFormat = "text"
 )
 
@@ -44,17 +52,38 @@ type logsOpts struct {
 	event    string
 	opid     string
 	extr     bool
+	follow   bool
 }
 
 type cliResult interface {
 	HasError() bool
 }
 
+// outw is where the command output goes (stdout or the --output-file).
+// Progress messages and errors in text format aren't affected by it.
+var outw io.Writer = os.Stdout
+
+// outFile is the --output-file, it's closed on exit
+var outFile *os.File
+
+// progw is where progress messages of the text output go
+var progw io.Writer = os.Stdout
+
+// quiet suppresses everything but errors, which are written to stderr
+var quiet bool
+
+// promptw is where confirmation prompts go. It's stderr so they neither
+// break the json/yaml output nor get hidden in the quiet mode
+var promptw io.Writer = os.Stderr
+
 func Main() {
 	var (
-		pbmCmd       = kingpin.New("pbm", "Percona Backup for MongoDB")
+		pbmCmd       = kingpin.New("pbm", "Percona Backup for MongoDB\n\n"+exitCodesHelp)
 		mURL         = pbmCmd.Flag("mongodb-uri", "MongoDB connection string (Default = PBM_MONGODB_URI environment variable)").Envar("PBM_MONGODB_URI").String()
-		pbmOutFormat = pbmCmd.Flag("out", "Output format <text>/<json>").Short('o').Default(string(outText)).Enum(string(outJSON), string(outJSONpretty), string(outText))
+		pbmOutFormat = pbmCmd.Flag("out", "Output format <text>/<json>/<yaml>").Short('o').Default(string(outText)).Enum(string(outJSON), string(outJSONpretty), string(outYAML), string(outText))
+		pbmOutFile   = pbmCmd.Flag("output-file", "Write the command output to the file instead of stdout").String()
+		pbmQuiet     = pbmCmd.Flag("quiet", "Don't print anything but errors. The exit code tells if the command succeeded").Short('q').Bool()
+		pbmTimeout   = pbmCmd.Flag("timeout", "Abort the command if it isn't finished in a given time (e.g. 30s, 1h). No limit by default").Duration()
 	)
 	pbmCmd.HelpFlag.Short('h')
 
@@ -62,6 +91,9 @@ func Main() {
 	versionShort := versionCmd.Flag("short", "Show only version info").Short('s').Default("false").Bool()
 	versionCommit := versionCmd.Flag("commit", "Show only git commit info").Short('c').Default("false").Bool()
 
+	completionCmd := pbmCmd.Command("completion", "Generate shell completion script. To enable it add `source <(pbm completion bash)` to ~/.bashrc or `source <(pbm completion zsh)` to ~/.zshrc")
+	completionShell := completionCmd.Arg("shell", "Shell type: bash or zsh").Required().Enum("bash", "zsh")
+
 	configCmd := pbmCmd.Command("config", "Set, change or list the config")
 	cfg := configOpts{set: make(map[string]string)}
 	configCmd.Flag("force-resync", "Resync backup list with the current store").BoolVar(&cfg.rsync)
@@ -72,8 +104,7 @@ func Main() {
 
 	backupCmd := pbmCmd.Command("backup", "Make backup")
 	backup := backupOpts{}
-	backupCmd.Flag("compression", "Compression type <none>/<gzip>/<snappy>/<lz4>/<s2>/<pgzip>/<zstd>").
-		Default(string(pbm.CompressionTypeS2)).
+	backupCmd.Flag("compression", "Compression type <none>/<gzip>/<snappy>/<lz4>/<s2>/<pgzip>/<zstd>. Defaults to backup.compression from the config or <s2>").
 		EnumVar(&backup.compression,
 			string(pbm.CompressionTypeNone), string(pbm.CompressionTypeGZIP),
 			string(pbm.CompressionTypeSNAPPY), string(pbm.CompressionTypeLZ4),
@@ -86,8 +117,18 @@ func Main() {
 			string(pbm.PhysicalBackup),
 			string(pbm.LogicalBackup),
 		)
-	backupCmd.Flag("compression-level", "Compression level (specific to the compression type)").
+	backupCmd.Flag("compression-level", "Compression level: 1-9 for <gzip>/<pgzip>, 1-4 for <s2>, 1-22 for <zstd>, 0 and above for <lz4>. Not supported by <snappy> and <none>").
 		IntsVar(&backup.compressionLevel)
+	backupCmd.Flag("compress-threads", "Number of compression threads for <s2>/<pgzip>/<zstd>. Defaults to a share of the agent's node CPUs").IntVar(&backup.compressThreads)
+	backupCmd.Flag("num-parallel-collections", "Number of collections to dump in parallel. Overrides the agents' --dump-parallel-collections").IntVar(&backup.parallelColls)
+	backupCmd.Flag("max-upload-mbps", "Limit the upload rate of each agent to the storage, in megabits per second. 0 for no limit").IntVar(&backup.maxUploadMbps)
+	backupCmd.Flag("upload-retries", "Number of times to retry a failed file upload before failing the physical backup").IntVar(&backup.uploadRetries)
+	backupCmd.Flag("tags-from-file", "Read backup labels from the YAML file with a key: value map. --label overrides them").StringVar(&backup.labelsFile)
+	backupCmd.Flag("label", "Tag the backup with the label in format key=value. Can be repeated").StringMapVar(&backup.labels)
+	backupCmd.Flag("wait", "Wait for the backup to finish").Short('w').BoolVar(&backup.wait)
+	backupCmd.Flag("metadata-out", "Write the backup metadata in JSON to the file once the backup is done. Requires --wait").StringVar(&backup.metaOut)
+	backupCmd.Flag("on-success", "Shell command to run once the backup successfully finished. Gets PBM_BACKUP_NAME, PBM_BACKUP_STORAGE and PBM_BACKUP_METADATA env vars. Requires --wait").StringVar(&backup.onSuccess)
+	backupCmd.Flag("dry-run", "Check the backup options and the storage access without starting the backup").BoolVar(&backup.dryRun)
 
 	cancelBcpCmd := pbmCmd.Command("cancel-backup", "Cancel backup")
 
@@ -97,7 +138,19 @@ func Main() {
 	restoreCmd.Flag("time", fmt.Sprintf("Restore to the point-in-time. Set in format %s", datetimeFormat)).StringVar(&restore.pitr)
 	restoreCmd.Flag("base-snapshot", "Override setting: Name of older snapshot that PITR will be based on during restore.").StringVar(&restore.pitrBase)
 	restoreCmd.Flag("wait", "Wait for the restore to finish.").Short('w').BoolVar(&restore.wait)
+	restoreCmd.Flag("yes", "Don't ask for the confirmation").Short('y').BoolVar(&restore.yes)
 	restoreCmd.Flag(RSMappingFlag, RSMappingDoc).Envar(RSMappingEnvVar).StringVar(&restore.rsMap)
+	restoreCmd.Flag("wait-for-replication", "Wait for secondaries to catch up before marking the restore as done").BoolVar(&restore.waitRepl)
+	restoreCmd.Flag("preflight-only", "Run the restore checks and exit without starting the restore").BoolVar(&restore.preflight)
+	restoreCmd.Flag("dry-run", "Same as --preflight-only").BoolVar(&restore.preflight)
+	restoreCmd.Flag("database", "Restore only the given database. Can be set multiple times").StringsVar(&restore.dbs)
+	restoreCmd.Flag("collection", "Restore only the given collection <db>.<collection>. Can be set multiple times").StringsVar(&restore.colls)
+	restoreCmd.Flag("max-oplog-lag", "Max replication lag of secondaries allowed with --wait-for-replication").Default("0s").
+		PreAction(func(*kingpin.ParseContext) error { restore.maxLagSet = true; return nil }).
+		DurationVar(&restore.maxLag)
+	restoreCmd.Flag("replication-timeout", "How long to wait for secondaries to catch up with --wait-for-replication").Default("10m").
+		PreAction(func(*kingpin.ParseContext) error { restore.replTimeoutSet = true; return nil }).
+		DurationVar(&restore.replTimeout)
 
 	replayCmd := pbmCmd.Command("oplog-replay", "Replay oplog")
 	replayOpts := replayOptions{}
@@ -114,7 +167,11 @@ func Main() {
 	listCmd.Flag("unbacked", "Show unbacked oplog ranges").Default("false").BoolVar(&list.unbacked)
 	listCmd.Flag("full", "Show extended restore info").Default("false").Short('f').Hidden().BoolVar(&list.full)
 	listCmd.Flag("size", "Show last N backups").Default("0").IntVar(&list.size)
+	listCmd.Flag("verbose", "Show backups size, compression and files").Short('v').BoolVar(&list.verbose)
 	listCmd.Flag(RSMappingFlag, RSMappingDoc).Envar(RSMappingEnvVar).StringVar(&list.rsMap)
+	listCmd.Flag("filter", "Show backups with the label in format key=value. Can be repeated, backups should match all of them").StringMapVar(&list.labels)
+	listCmd.Flag("since", fmt.Sprintf("Show backups started since the time. Set in format %s, RFC3339 or relative to now (e.g. 12h, 7d)", datetimeFormat)).StringVar(&list.since)
+	listCmd.Flag("until", fmt.Sprintf("Show backups started until the time. Set in format %s, RFC3339 or relative to now (e.g. 12h, 7d)", datetimeFormat)).StringVar(&list.until)
 
 	deleteBcpCmd := pbmCmd.Command("delete-backup", "Delete a backup")
 	deleteBcp := deleteBcpOpts{}
@@ -122,6 +179,27 @@ func Main() {
 	deleteBcpCmd.Flag("older-than", fmt.Sprintf("Delete backups older than date/time in format %s or %s", datetimeFormat, dateFormat)).StringVar(&deleteBcp.olderThan)
 	deleteBcpCmd.Flag("force", "Force. Don't ask confirmation").Short('f').BoolVar(&deleteBcp.force)
 
+	describeBcpCmd := pbmCmd.Command("describe-backup", "Show detailed info about the backup")
+	describeBcpName := describeBcpCmd.Arg("name", "Backup name").Required().String()
+
+	validateBcpCmd := pbmCmd.Command("validate-backup", "Check that backup files are on the storage and consistent with the backup metadata")
+	validateBcpName := validateBcpCmd.Arg("name", "Backup name").Required().String()
+
+	pruneCmd := pbmCmd.Command("prune", "Delete backups exceeding the retention policy")
+	pruneBcp := pruneOpts{}
+	pruneCmd.Flag("keep-last", "Keep last N successful backups").IntVar(&pruneBcp.keepLast)
+	pruneCmd.Flag("keep-within", "Keep backups started within the interval (e.g. 72h, 7d)").StringVar(&pruneBcp.keepWithin)
+	pruneCmd.Flag("dry-run", "Only print backups that would be deleted").BoolVar(&pruneBcp.dryRun)
+	pruneCmd.Flag("force", "Force. Don't ask confirmation").Short('f').BoolVar(&pruneBcp.force)
+
+	catalogCmd := pbmCmd.Command("catalog", "Export or import the backups metadata")
+	catalog := catalogOpts{}
+	catalogExportCmd := catalogCmd.Command("export", "Write metadata of successfully finished backups to the JSON file. Storage credentials are left out")
+	catalogExportCmd.Arg("file", "File to write to. Must not exist").Required().StringVar(&catalog.file)
+	catalogImportCmd := catalogCmd.Command("import", "Add backups metadata from the file made by `catalog export`. Backups files have to be on the current storage")
+	catalogImportCmd.Arg("file", "File to read from").Required().StringVar(&catalog.file)
+	catalogImportCmd.Flag("overwrite", "Replace backups with the same names").BoolVar(&catalog.overwrite)
+
 	deletePitrCmd := pbmCmd.Command("delete-pitr", "Delete PITR chunks")
 	deletePitr := deletePitrOpts{}
 	deletePitrCmd.Flag("older-than", fmt.Sprintf("Delete backups older than date/time in format %s or %s", datetimeFormat, dateFormat)).StringVar(&deletePitr.olderThan)
@@ -135,8 +213,13 @@ func Main() {
 	logsCmd.Flag("severity", "Severity level D, I, W, E or F, low to high. Choosing one includes higher levels too.").Short('s').Default("I").EnumVar(&logs.severity, "D", "I", "W", "E", "F")
 	logsCmd.Flag("event", "Event in format backup[/2020-10-06T11:45:14Z]. Events: backup, restore, cancelBackup, resync, pitr, pitrestore, delete").Short('e').StringVar(&logs.event)
 	logsCmd.Flag("opid", "Operation ID").Short('i').StringVar(&logs.opid)
+	logsCmd.Flag("follow", "Keep showing new log entries as they appear, until interrupted").Short('f').BoolVar(&logs.follow)
 	logsCmd.Flag("extra", "Show extra data in text format").Hidden().Short('x').BoolVar(&logs.extr)
 
+	healthCmd := pbmCmd.Command("health", "Check that all agents are alive and can access the storage. Exits non-zero if any check fails")
+
+	checkStorageCmd := pbmCmd.Command("check-storage", "Make every agent write and delete a probe file on the configured storage")
+
 	statusCmd := pbmCmd.Command("status", "Show PBM status")
 	var statusRSMap string
 	statusCmd.Flag(RSMappingFlag, RSMappingDoc).Envar(RSMappingEnvVar).StringVar(&statusRSMap)
@@ -145,11 +228,62 @@ func Main() {
 	cmd, err := pbmCmd.DefaultEnvars().Parse(os.Args[1:])
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error: parse command line parameters:", err)
-		os.Exit(1)
+		os.Exit(ExitUsage)
 	}
 	pbmOutF := outFormat(*pbmOutFormat)
+
+	if cmd == restoreCmd.FullCommand() && !restore.waitRepl {
+		if restore.maxLagSet {
+			exitErrCode(errors.New("--max-oplog-lag requires --wait-for-replication"), pbmOutF, ExitUsage)
+		}
+		if restore.replTimeoutSet {
+			exitErrCode(errors.New("--replication-timeout requires --wait-for-replication"), pbmOutF, ExitUsage)
+		}
+	}
 	var out fmt.Stringer
 
+	if *pbmQuiet {
+		outw = ioutil.Discard
+		progw = ioutil.Discard
+		quiet = true
+	}
+	// progress dots would break the structured output
+	if pbmOutF != outText {
+		progw = ioutil.Discard
+	}
+
+	if *pbmOutFile != "" {
+		f, err := os.Create(*pbmOutFile)
+		if err != nil {
+			exitErr(errors.Wrap(err, "create output file"), pbmOutF)
+		}
+		outFile = f
+		outw = f
+		// a normal return, exit() closes it otherwise
+		defer func() {
+			err := f.Close()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error: close output file:", err)
+				os.Exit(ExitFailure)
+			}
+		}()
+	}
+
+	if cmd == completionCmd.FullCommand() {
+		tmpl := kingpin.BashCompletionTemplate
+		if *completionShell == "zsh" {
+			tmpl = kingpin.ZshCompletionTemplate
+		}
+		pctx, err := pbmCmd.ParseContext(nil)
+		if err == nil {
+			err = pbmCmd.Writer(outw).UsageForContextWithTemplate(pctx, 2, tmpl)
+		}
+		if err != nil {
+			exitErr(errors.Wrap(err, "generate completion script"), pbmOutF)
+		}
+		return
+	}
+
 	if cmd == versionCmd.FullCommand() {
 		switch {
 		case *versionCommit:
@@ -167,15 +301,31 @@ func Main() {
 		fmt.Fprintln(os.Stderr, "Error: no mongodb connection URI supplied")
 		fmt.Fprintln(os.Stderr, "       Usual practice is the set it by the PBM_MONGODB_URI environment variable. It can also be set with commandline argument --mongodb-uri.")
 		pbmCmd.Usage(os.Args[1:])
-		os.Exit(1)
+		exit(ExitUsage)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// an interrupted command stops waiting for the operation, the operation
+	// itself keeps running on agents (use `pbm cancel-backup` to stop a backup).
+	// The second signal terminates the process right away.
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigc
+		cancel()
+		signal.Stop(sigc)
+	}()
+	if *pbmTimeout > 0 {
+		var tcancel context.CancelFunc
+		ctx, tcancel = context.WithTimeout(ctx, *pbmTimeout)
+		defer tcancel()
+	}
+
 	pbmClient, err := pbm.New(ctx, *mURL, "pbm-ctl")
 	if err != nil {
-		exitErr(errors.Wrap(err, "connect to mongodb"), pbmOutF)
+		exitErrCode(errors.Wrap(err, "connect to mongodb"), pbmOutF, connErrExitCode(err))
 	}
 
 	pbmClient.InitLogger("", "")
@@ -185,7 +335,7 @@ func Main() {
 		out, err = runConfig(pbmClient, &cfg)
 	case backupCmd.FullCommand():
 		backup.name = time.Now().UTC().Format(time.RFC3339)
-		out, err = runBackup(pbmClient, &backup, pbmOutF)
+		out, err = runBackup(pbmClient, &backup, *mURL, pbmOutF)
 	case cancelBcpCmd.FullCommand():
 		out, err = cancelBcp(pbmClient)
 	case restoreCmd.FullCommand():
@@ -196,22 +346,49 @@ func Main() {
 		out, err = runList(pbmClient, &list)
 	case deleteBcpCmd.FullCommand():
 		out, err = deleteBackup(pbmClient, &deleteBcp, pbmOutF)
+	case describeBcpCmd.FullCommand():
+		out, err = describeBackup(pbmClient, *describeBcpName)
+	case validateBcpCmd.FullCommand():
+		out, err = validateBackup(pbmClient, *validateBcpName)
+	case pruneCmd.FullCommand():
+		out, err = prune(pbmClient, &pruneBcp, pbmOutF)
+	case catalogExportCmd.FullCommand():
+		out, err = exportCatalog(pbmClient, &catalog)
+	case catalogImportCmd.FullCommand():
+		out, err = importCatalog(pbmClient, &catalog)
 	case deletePitrCmd.FullCommand():
 		out, err = deletePITR(pbmClient, &deletePitr, pbmOutF)
 	case logsCmd.FullCommand():
-		out, err = runLogs(pbmClient, &logs)
+		out, err = runLogs(pbmClient, &logs, pbmOutF)
+	case healthCmd.FullCommand():
+		out, err = health(pbmClient, *mURL)
+	case checkStorageCmd.FullCommand():
+		out, err = checkStorage(pbmClient, pbmOutF)
 	case statusCmd.FullCommand():
 		out, err = status(pbmClient, *mURL, statusSection, statusRSMap, pbmOutF == outJSONpretty)
 	}
 
 	if err != nil {
+		switch ctx.Err() {
+		case context.DeadlineExceeded:
+			exitErrCode(errors.Wrapf(err, "operation timed out after %v", *pbmTimeout), pbmOutF, ExitTimeout)
+		case context.Canceled:
+			exitErr(errors.Wrap(err, "interrupted"), pbmOutF)
+		}
 		exitErr(err, pbmOutF)
 	}
 
 	printo(out, pbmOutF)
 
 	if r, ok := out.(cliResult); ok && r.HasError() {
-		os.Exit(1)
+		if quiet {
+			fmt.Fprintln(os.Stderr, strings.TrimSpace(out.String()))
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			fmt.Fprintf(os.Stderr, "Error: operation timed out after %v\n", *pbmTimeout)
+			exit(ExitTimeout)
+		}
+		exit(ExitFailure)
 	}
 }
 
@@ -220,39 +397,77 @@ func printo(out fmt.Stringer, f outFormat) {
 		return
 	}
 
-	switch f {
-	case outJSON:
-		err := json.NewEncoder(os.Stdout).Encode(out)
+	if f == outText {
+		_, err := fmt.Fprintln(outw, strings.TrimSpace(out.String()))
 		if err != nil {
-			exitErr(errors.Wrap(err, "encode output"), f)
+			exitErr(errors.Wrap(err, "write output"), f)
 		}
+		return
+	}
+
+	err := encodeOut(outw, out, f)
+	if err != nil {
+		exitErr(errors.Wrap(err, "encode output"), f)
+	}
+}
+
+// encodeOut writes v to w in the given structured (json/yaml) format
+func encodeOut(w io.Writer, v interface{}, f outFormat) error {
+	switch f {
 	case outJSONpretty:
-		enc := json.NewEncoder(os.Stdout)
+		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
-		err := enc.Encode(out)
+		return enc.Encode(v)
+	case outYAML:
+		b, err := toYAML(v)
 		if err != nil {
-			exitErr(errors.Wrap(err, "encode output"), f)
+			return err
 		}
+		_, err = w.Write(b)
+		return errors.Wrap(err, "write")
 	default:
-		fmt.Println(strings.TrimSpace(out.String()))
+		return json.NewEncoder(w).Encode(v)
+	}
+}
+
+// toYAML encodes v in YAML. It goes through JSON so the output has
+// the same fields as the JSON one, including custom marshalers.
+// JSON is valid YAML hence can be decoded with the keys order preserved.
+func toYAML(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
 	}
+
+	var m yaml.MapSlice
+	err = yaml.Unmarshal(b, &m)
+	if err != nil {
+		var a interface{}
+		err = yaml.Unmarshal(b, &a)
+		if err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(a)
+	}
+
+	return yaml.Marshal(m)
 }
 
 func exitErr(e error, f outFormat) {
-	switch f {
-	case outJSON, outJSONpretty:
+	exitErrCode(e, f, errExitCode(e))
+}
+
+func exitErrCode(e error, f outFormat, code int) {
+	switch {
+	case quiet:
+		fmt.Fprintln(os.Stderr, "Error:", e)
+	case f != outText:
 		var m interface{}
 		m = e
 		if _, ok := e.(json.Marshaler); !ok {
 			m = map[string]string{"Error": e.Error()}
 		}
-		var err error
-		if f == outJSONpretty {
-			err = json.NewEncoder(os.Stdout).Encode(m)
-		} else {
-			err = json.NewEncoder(os.Stdout).Encode(m)
-		}
-
+		err := encodeOut(outw, m, f)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: encoding error \"%v\": %v", m, err)
 		}
@@ -260,10 +475,26 @@ func exitErr(e error, f outFormat) {
 		fmt.Fprintln(os.Stderr, "Error:", e)
 	}
 
-	os.Exit(1)
+	exit(code)
+}
+
+// exit closes the output file, if any, and exits with the code.
+// A failed close means the output is lost, so it's a failure.
+func exit(code int) {
+	if outFile != nil {
+		err := outFile.Close()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error: close output file:", err)
+			if code == ExitOK {
+				code = ExitFailure
+			}
+		}
+	}
+
+	os.Exit(code)
 }
 
-func runLogs(cn *pbm.PBM, l *logsOpts) (fmt.Stringer, error) {
+func runLogs(cn *pbm.PBM, l *logsOpts, outf outFormat) (fmt.Stringer, error) {
 	r := &plog.LogRequest{}
 
 	if l.node != "" {
@@ -315,7 +546,27 @@ func runLogs(cn *pbm.PBM, l *logsOpts) (fmt.Stringer, error) {
 		o.Data[i], o.Data[opp] = o.Data[opp], o.Data[i]
 	}
 
-	return o, nil
+	if !l.follow {
+		return o, nil
+	}
+
+	var last primitive.ObjectID
+	if len(o.Data) > 0 {
+		printo(o, outf)
+		last = o.Data[len(o.Data)-1].ObjID
+	}
+	err = cn.LogFollow(r, last, func(e *plog.Entry) error {
+		if outf != outText {
+			printo(e, outf)
+			return nil
+		}
+		printo(&plog.Entries{Data: []plog.Entry{*e}, ShowNode: o.ShowNode, Extr: o.Extr}, outf)
+		return nil
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil, nil
+	}
+	return nil, errors.Wrap(err, "follow logs")
 }
 
 type snapshotStat struct {
@@ -326,6 +577,10 @@ type snapshotStat struct {
 	StateTS    int64          `json:"completeTS"`
 	PBMVersion string         `json:"pbmVersion"`
 	Type       pbm.BackupType `json:"type"`
+	// set only for the verbose output
+	Compression pbm.CompressionType `json:"compression,omitempty"`
+	Files       map[string][]string `json:"files,omitempty"`
+	Labels      map[string]string   `json:"labels,omitempty"`
 }
 
 type pitrRange struct {
@@ -435,7 +690,7 @@ var errTout = errors.Errorf("timeout reached")
 func waitOp(pbmClient *pbm.PBM, lock *pbm.LockHeader, waitFor time.Duration) error {
 	// just to be sure the check hasn't started before the lock were created
 	time.Sleep(1 * time.Second)
-	fmt.Print(".")
+	fmt.Fprint(progw, ".")
 
 	tmr := time.NewTimer(waitFor)
 	defer tmr.Stop()
@@ -446,7 +701,7 @@ func waitOp(pbmClient *pbm.PBM, lock *pbm.LockHeader, waitFor time.Duration) err
 		case <-tmr.C:
 			return errTout
 		case <-tkr.C:
-			fmt.Print(".")
+			fmt.Fprint(progw, ".")
 			lock, err := pbmClient.GetLockData(lock)
 			if err != nil {
 				// No lock, so operation has finished
@@ -466,6 +721,32 @@ func waitOp(pbmClient *pbm.PBM, lock *pbm.LockHeader, waitFor time.Duration) err
 	}
 }
 
+// confirm asks the y/N question and reports whether the answer is yes.
+// Reading stdin can't be interrupted, so it's done in a goroutine
+// to return as soon as ctx is canceled (e.g. by Ctrl-C).
+func confirm(ctx context.Context, question string) (bool, error) {
+	fmt.Fprint(promptw, question+" [y/N] ")
+
+	ans := make(chan string, 1)
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Scan()
+		ans <- scanner.Text()
+	}()
+
+	select {
+	case <-ctx.Done():
+		fmt.Fprintln(promptw)
+		return false, ctx.Err()
+	case a := <-ans:
+		switch strings.TrimSpace(a) {
+		case "yes", "Yes", "YES", "Y", "y":
+			return true, nil
+		}
+		return false, nil
+	}
+}
+
 func isTTY() bool {
 	fi, err := os.Stdin.Stat()
 	return (fi.Mode()&os.ModeCharDevice) != 0 && err == nil
@@ -74,7 +74,11 @@ func runConfig(cn *pbm.PBM, c *configOpts) (fmt.Stringer, error) {
 		}
 		return outMsg{"Storage resync started"}, nil
 	case len(c.file) > 0:
-		buf, err := ioutil.ReadFile(c.file)
+		fname, err := expandPath(c.file)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read config file")
+		}
+		buf, err := ioutil.ReadFile(fname)
 		if err != nil {
 			return nil, errors.Wrap(err, "unable to read config file")
 		}
@@ -1,10 +1,7 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
 	"time"
 
 	"github.com/percona/percona-backup-mongodb/pbm"
@@ -19,12 +16,11 @@ type deleteBcpOpts struct {
 
 func deleteBackup(pbmClient *pbm.PBM, d *deleteBcpOpts, outf outFormat) (fmt.Stringer, error) {
 	if !d.force && isTTY() {
-		fmt.Print("Are you sure you want delete backup(s)? [y/N] ")
-		scanner := bufio.NewScanner(os.Stdin)
-		scanner.Scan()
-		switch strings.TrimSpace(scanner.Text()) {
-		case "yes", "Yes", "YES", "Y", "y":
-		default:
+		ok, err := confirm(pbmClient.Context(), "Are you sure you want delete backup(s)?")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
 			return nil, nil
 		}
 	}
@@ -44,44 +40,56 @@ func deleteBackup(pbmClient *pbm.PBM, d *deleteBcpOpts, outf outFormat) (fmt.Str
 		}
 		cmd.Delete.Backup = d.name
 	}
+
+	err := sendDeleteBackup(pbmClient, cmd, outf)
+	if err != nil || outf != outText {
+		return nil, err
+	}
+
+	return runList(pbmClient, &listOpts{})
+}
+
+// sendDeleteBackup sends the delete backup command and, in case of the text
+// output, waits for the agents to finish it
+func sendDeleteBackup(pbmClient *pbm.PBM, cmd pbm.Cmd, outf outFormat) error {
 	tsop := time.Now().UTC().Unix()
 	err := pbmClient.SendCmd(cmd)
 	if err != nil {
-		return nil, errors.Wrap(err, "schedule delete")
+		return errors.Wrap(err, "schedule delete")
 	}
 	if outf != outText {
-		return nil, nil
+		return nil
 	}
 
-	fmt.Print("Waiting for delete to be done ")
+	fmt.Fprint(progw, "Waiting for delete to be done ")
 	err = waitOp(pbmClient,
 		&pbm.LockHeader{
 			Type: pbm.CmdDeleteBackup,
 		},
 		time.Second*60)
 	if err != nil && err != errTout {
-		return nil, err
+		return err
 	}
 
 	errl, err := lastLogErr(pbmClient, pbm.CmdDeleteBackup, tsop)
 	if err != nil {
-		return nil, errors.Wrap(err, "read agents log")
+		return errors.Wrap(err, "read agents log")
 	}
 
 	if errl != "" {
-		return nil, errors.New(errl)
+		return errors.New(errl)
 	}
 
 	if err == errTout {
-		fmt.Println("\nOperation is still in progress, please check status in a while")
+		fmt.Fprintln(progw, "\nOperation is still in progress, please check status in a while")
 	} else {
 		time.Sleep(time.Second)
-		fmt.Print(".")
+		fmt.Fprint(progw, ".")
 		time.Sleep(time.Second)
-		fmt.Println("[done]")
+		fmt.Fprintln(progw, "[done]")
 	}
 
-	return runList(pbmClient, &listOpts{})
+	return nil
 }
 
 type deletePitrOpts struct {
@@ -100,12 +108,11 @@ func deletePITR(pbmClient *pbm.PBM, d *deletePitrOpts, outf outFormat) (fmt.Stri
 		if d.all {
 			all = " ALL"
 		}
-		fmt.Printf("Are you sure you want delete%s chunks? [y/N] ", all)
-		scanner := bufio.NewScanner(os.Stdin)
-		scanner.Scan()
-		switch strings.TrimSpace(scanner.Text()) {
-		case "yes", "Yes", "YES", "Y", "y":
-		default:
+		ok, err := confirm(pbmClient.Context(), fmt.Sprintf("Are you sure you want delete%s chunks?", all))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
 			return nil, nil
 		}
 	}
@@ -129,7 +136,7 @@ func deletePITR(pbmClient *pbm.PBM, d *deletePitrOpts, outf outFormat) (fmt.Stri
 		return nil, nil
 	}
 
-	fmt.Print("Waiting for delete to be done ")
+	fmt.Fprint(progw, "Waiting for delete to be done ")
 	err = waitOp(pbmClient,
 		&pbm.LockHeader{
 			Type: pbm.CmdDeletePITR,
@@ -149,13 +156,122 @@ func deletePITR(pbmClient *pbm.PBM, d *deletePitrOpts, outf outFormat) (fmt.Stri
 	}
 
 	if err == errTout {
-		fmt.Println("\nOperation is still in progress, please check status in a while")
+		fmt.Fprintln(progw, "\nOperation is still in progress, please check status in a while")
 	} else {
 		time.Sleep(time.Second)
-		fmt.Print(".")
+		fmt.Fprint(progw, ".")
 		time.Sleep(time.Second)
-		fmt.Println("[done]")
+		fmt.Fprintln(progw, "[done]")
 	}
 
 	return runList(pbmClient, &listOpts{})
 }
+
+type pruneOpts struct {
+	keepLast   int
+	keepWithin string
+	dryRun     bool
+	force      bool
+}
+
+type pruneOut struct {
+	DryRun  bool     `json:"dryRun"`
+	Backups []string `json:"backups"`
+}
+
+func (p pruneOut) String() string {
+	if len(p.Backups) == 0 {
+		return "Nothing to prune"
+	}
+
+	s := "Backups to be deleted:\n"
+	if !p.DryRun {
+		s = "Deleting backups:\n"
+	}
+	for _, b := range p.Backups {
+		s += "  " + b + "\n"
+	}
+
+	return s
+}
+
+// prune deletes backups exceeding the retention policy. A backup is retained
+// if it is among the last `keepLast` successful backups or if it was started
+// within the `keepWithin` interval. Everything older than the oldest retained
+// backup is deleted except the backups the PITR timeline depends on.
+func prune(pbmClient *pbm.PBM, o *pruneOpts, outf outFormat) (fmt.Stringer, error) {
+	if o.keepLast <= 0 && o.keepWithin == "" {
+		return nil, errors.New("either --keep-last or --keep-within should be set")
+	}
+
+	now := time.Now().UTC()
+	cutoff := now
+	if o.keepWithin != "" {
+		t, err := parseTimeBound(o.keepWithin, now)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse --keep-within")
+		}
+		cutoff = t
+	}
+
+	bcps, err := pbmClient.BackupsList(0)
+	if err != nil {
+		return nil, errors.Wrap(err, "get backups list")
+	}
+
+	if o.keepLast > 0 {
+		n := 0
+		for _, b := range bcps {
+			if b.Status != pbm.StatusDone {
+				continue
+			}
+			n++
+			if n == o.keepLast {
+				if bt := time.Unix(b.StartTS, 0); bt.Before(cutoff) {
+					cutoff = bt
+				}
+				break
+			}
+		}
+		// there are less successful backups than should be kept
+		if n < o.keepLast {
+			return pruneOut{DryRun: o.dryRun}, nil
+		}
+	}
+
+	// the same list agents delete, with backups needed for PITR left out
+	del, err := pbmClient.BackupsToDelete(cutoff, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "get backups to delete")
+	}
+
+	out := pruneOut{DryRun: o.dryRun, Backups: []string{}}
+	for _, b := range del {
+		out.Backups = append(out.Backups, b.Name)
+	}
+
+	if o.dryRun || len(out.Backups) == 0 {
+		return out, nil
+	}
+
+	if !o.force && isTTY() {
+		ok, err := confirm(pbmClient.Context(), fmt.Sprintf("Are you sure you want delete %d backup(s)?", len(out.Backups)))
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	cmd := pbm.Cmd{
+		Cmd: pbm.CmdDeleteBackup,
+	}
+	cmd.Delete.OlderThan = cutoff.Unix()
+	err = sendDeleteBackup(pbmClient, cmd, outf)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
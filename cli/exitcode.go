@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+
+	"github.com/percona/percona-backup-mongodb/pbm"
+)
+
+// Exit codes of the pbm command. It's a contract for scripts and
+// automation, so the existing values must not be changed.
+const (
+	ExitOK          = 0 // command succeeded
+	ExitFailure     = 1 // command failed
+	ExitUsage       = 2 // wrong command line parameters
+	ExitTimeout     = 4 // operation timed out
+	ExitServerError = 5 // unable to connect to the cluster
+	ExitAuth        = 6 // authentication or TLS failure
+	ExitConflict    = 7 // another operation is in progress
+	ExitNotFound    = 8 // backup or other requested entity not found
+)
+
+const exitCodesHelp = `Exit codes:
+  0  success
+  1  failure
+  2  invalid arguments
+  4  operation timed out
+  5  unable to connect to the cluster
+  6  authentication or TLS failure
+  7  another operation is in progress
+  8  requested backup or entity not found`
+
+// errExitCode returns the exit code for the given error
+func errExitCode(err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ExitTimeout
+	case errors.As(err, &concurentOpErr{}):
+		return ExitConflict
+	case errors.Is(err, pbm.ErrNotFound), errors.Is(err, mongo.ErrNoDocuments):
+		return ExitNotFound
+	}
+
+	return ExitFailure
+}
+
+// connErrExitCode returns the exit code for the cluster connection error
+func connErrExitCode(err error) int {
+	if isAuthErr(err) {
+		return ExitAuth
+	}
+
+	// the server selection hides the actual cause in the servers' descriptions
+	var serr topology.ServerSelectionError
+	if errors.As(err, &serr) {
+		for _, s := range serr.Desc.Servers {
+			if s.LastError != nil && isAuthErr(s.LastError) {
+				return ExitAuth
+			}
+		}
+	}
+
+	return ExitServerError
+}
+
+func isAuthErr(err error) bool {
+	var aerr *auth.Error
+	var uaerr x509.UnknownAuthorityError
+	var herr x509.HostnameError
+	var cerr x509.CertificateInvalidError
+
+	return errors.As(err, &aerr) ||
+		errors.As(err, &uaerr) ||
+		errors.As(err, &herr) ||
+		errors.As(err, &cerr)
+}
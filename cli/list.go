@@ -3,6 +3,8 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -19,6 +21,10 @@ type listOpts struct {
 	full        bool
 	size        int
 	rsMap       string
+	since       string
+	until       string
+	verbose     bool
+	labels      map[string]string
 }
 
 type restoreStatus struct {
@@ -95,7 +101,22 @@ func runList(cn *pbm.PBM, l *listOpts) (fmt.Stringer, error) {
 		return outMsg{"Storage resync is running. Backups list will be available after sync finishes."}, nil
 	}
 
-	return backupList(cn, l.size, l.full, l.unbacked, rsMap)
+	var since, until time.Time
+	now := time.Now().UTC()
+	if l.since != "" {
+		since, err = parseTimeBound(l.since, now)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse --since")
+		}
+	}
+	if l.until != "" {
+		until, err = parseTimeBound(l.until, now)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse --until")
+		}
+	}
+
+	return backupList(cn, l.size, l.full, l.unbacked, l.verbose, rsMap, since, until, l.labels)
 }
 
 func restoreList(cn *pbm.PBM, size int64, full bool) (*restoreListOut, error) {
@@ -140,12 +161,40 @@ type backupListOut struct {
 		Ranges   []pitrRange            `json:"ranges"`
 		RsRanges map[string][]pitrRange `json:"rsRanges,omitempty"`
 	} `json:"pitr"`
+	verbose bool
 }
 
 func (bl backupListOut) String() string {
 	s := fmt.Sprintln("Backup snapshots:")
 	for _, b := range bl.Snapshots {
 		s += fmt.Sprintf("  %s <%s> [complete: %s]\n", b.Name, b.Type, fmtTS(int64(b.StateTS)))
+		if !bl.verbose {
+			continue
+		}
+		if len(b.Labels) > 0 {
+			lbs := make([]string, 0, len(b.Labels))
+			for k, v := range b.Labels {
+				lbs = append(lbs, k+"="+v)
+			}
+			sort.Strings(lbs)
+			s += fmt.Sprintf("    labels: %s\n", strings.Join(lbs, ", "))
+		}
+		if b.Err != "" {
+			s += fmt.Sprintf("    size: unknown (%s)\n", b.Err)
+		} else {
+			s += fmt.Sprintf("    size: %s, compression: %s\n", fmtSize(b.Size), b.Compression)
+		}
+		rss := make([]string, 0, len(b.Files))
+		for rs := range b.Files {
+			rss = append(rss, rs)
+		}
+		sort.Strings(rss)
+		for _, rs := range rss {
+			s += fmt.Sprintf("    %s:\n", rs)
+			for _, f := range b.Files[rs] {
+				s += fmt.Sprintf("      %s\n", f)
+			}
+		}
 	}
 	if bl.PITR.On {
 		s += fmt.Sprintln("\nPITR <on>:")
@@ -170,8 +219,9 @@ func (bl backupListOut) String() string {
 	return s
 }
 
-func backupList(cn *pbm.PBM, size int, full, unbacked bool, rsMap map[string]string) (list backupListOut, err error) {
-	list.Snapshots, err = getSnapshotList(cn, size, rsMap)
+func backupList(cn *pbm.PBM, size int, full, unbacked, verbose bool, rsMap map[string]string, since, until time.Time, labels map[string]string) (list backupListOut, err error) {
+	list.verbose = verbose
+	list.Snapshots, err = getSnapshotList(cn, size, rsMap, since, until, labels)
 	if err != nil {
 		return list, errors.Wrap(err, "get snapshots")
 	}
@@ -185,15 +235,90 @@ func backupList(cn *pbm.PBM, size int, full, unbacked bool, rsMap map[string]str
 		return list, errors.Wrap(err, "check if PITR is on")
 	}
 
+	if verbose {
+		err = fillSnapshotDetails(cn, list.Snapshots)
+		if err != nil {
+			return list, err
+		}
+	}
+
 	return list, nil
 }
 
-func getSnapshotList(cn *pbm.PBM, size int, rsMapping map[string]string) (s []snapshotStat, err error) {
-	bcps, err := cn.BackupsList(int64(size))
+// fillSnapshotDetails sets the size, compression and files of given snapshots
+func fillSnapshotDetails(cn *pbm.PBM, snapshots []snapshotStat) error {
+	stg, err := cn.GetStorage(cn.Logger().NewEvent("", "", "", primitive.Timestamp{}))
+	if err != nil {
+		return errors.Wrap(err, "get storage")
+	}
+
+	for i := range snapshots {
+		bcp, err := cn.GetBackupMeta(snapshots[i].Name)
+		if err != nil {
+			return errors.Wrapf(err, "get backup meta %s", snapshots[i].Name)
+		}
+
+		snapshots[i].Compression = bcp.Compression
+		snapshots[i].Files = make(map[string][]string)
+		for _, rs := range bcp.Replsets {
+			if bcp.Type == pbm.PhysicalBackup {
+				for _, f := range rs.Files {
+					snapshots[i].Files[rs.Name] = append(snapshots[i].Files[rs.Name], f.Name)
+				}
+				continue
+			}
+			snapshots[i].Files[rs.Name] = []string{rs.DumpName, rs.OplogName}
+		}
+
+		switch bcp.Type {
+		case pbm.PhysicalBackup:
+			snapshots[i].Size, err = getPhysSnapshotSize(bcp, stg)
+		default:
+			snapshots[i].Size, err = getSnapshotSize(bcp.Replsets, stg)
+		}
+		if err != nil {
+			snapshots[i].Err = err.Error()
+		}
+	}
+
+	return nil
+}
+
+// getSnapshotList returns last `size` snapshots. If `since` or `until` is set,
+// only snapshots started within that time range are returned.
+func getSnapshotList(cn *pbm.PBM, size int, rsMapping map[string]string, since, until time.Time, labels map[string]string) (s []snapshotStat, err error) {
+	filter := !since.IsZero() || !until.IsZero() || len(labels) > 0
+
+	limit := int64(size)
+	if filter {
+		limit = 0
+	}
+	bcps, err := cn.BackupsList(limit)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get backups list")
 	}
 
+	if filter {
+		var fbcps []pbm.BackupMeta
+		for _, b := range bcps {
+			if b.StartTS == 0 {
+				continue
+			}
+			start := time.Unix(b.StartTS, 0)
+			if (!since.IsZero() && start.Before(since)) || (!until.IsZero() && start.After(until)) {
+				continue
+			}
+			if !matchLabels(b.Labels, labels) {
+				continue
+			}
+			fbcps = append(fbcps, b)
+		}
+		bcps = fbcps
+		if size > 0 && size < len(bcps) {
+			bcps = bcps[:size]
+		}
+	}
+
 	shards, err := cn.ClusterMembers()
 	if err != nil {
 		return nil, errors.Wrap(err, "get cluster members")
@@ -224,12 +349,24 @@ func getSnapshotList(cn *pbm.PBM, size int, rsMapping map[string]string) (s []sn
 			StateTS:    int64(b.LastWriteTS.T),
 			PBMVersion: b.PBMVersion,
 			Type:       b.Type,
+			Labels:     b.Labels,
 		})
 	}
 
 	return s, nil
 }
 
+// matchLabels returns true if all of the given labels are set for the backup
+func matchLabels(bcp, labels map[string]string) bool {
+	for k, v := range labels {
+		if bv, ok := bcp[k]; !ok || bv != v {
+			return false
+		}
+	}
+
+	return true
+}
+
 // getPitrList shows only chunks derived from `Done` and compatible version's backups
 func getPitrList(cn *pbm.PBM, size int, full, unbacked bool, rsMap map[string]string) (ranges []pitrRange, rsRanges map[string][]pitrRange, err error) {
 	inf, err := cn.GetNodeInfo()
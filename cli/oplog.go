@@ -69,7 +69,7 @@ func replayOplog(cn *pbm.PBM, o replayOptions, outf outFormat) (fmt.Stringer, er
 		return oplogReplayResult{Name: name}, nil
 	}
 
-	fmt.Printf("Starting oplog reply '%s - %s'", o.start, o.end)
+	fmt.Fprintf(progw, "Starting oplog reply '%s - %s'", o.start, o.end)
 
 	ctx, cancel := context.WithTimeout(context.Background(), pbm.WaitActionStart)
 	defer cancel()
@@ -83,7 +83,7 @@ func replayOplog(cn *pbm.PBM, o replayOptions, outf outFormat) (fmt.Stringer, er
 		return oplogReplayResult{Name: name}, nil
 	}
 
-	fmt.Print("Started.\nWaiting to finish")
+	fmt.Fprint(progw, "Started.\nWaiting to finish")
 	err = waitRestore(cn, m)
 	if err != nil {
 		return oplogReplayResult{err: err.Error()}, nil
@@ -4,23 +4,40 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/mongodb/mongo-tools/common/archive"
+	"github.com/mongodb/mongo-tools/mongorestore/ns"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/percona/percona-backup-mongodb/pbm"
+	prestore "github.com/percona/percona-backup-mongodb/pbm/restore"
 	"github.com/percona/percona-backup-mongodb/pbm/storage"
+	"github.com/percona/percona-backup-mongodb/version"
 )
 
 type restoreOpts struct {
-	bcp      string
-	pitr     string
-	pitrBase string
-	wait     bool
-	rsMap    string
+	bcp       string
+	pitr      string
+	pitrBase  string
+	wait      bool
+	rsMap     string
+	waitRepl  bool
+	maxLag    time.Duration
+	maxLagSet bool
+	// replTimeout is how long agents wait for the replication
+	replTimeout    time.Duration
+	replTimeoutSet bool
+	preflight      bool
+	dbs            []string
+	colls          []string
+	yes            bool
 }
 
 type restoreRet struct {
@@ -28,6 +45,9 @@ type restoreRet struct {
 	Snapshot string `json:"snapshot,omitempty"`
 	PITR     string `json:"point-in-time,omitempty"`
 	Leader   string `json:"leader,omitempty"`
+	// ReplLag is the replication lag of secondaries in seconds by replsets
+	// when the restore was done with --wait-for-replication
+	ReplLag  map[string]map[string]int `json:"replicationLag,omitempty"`
 	done     bool
 	physical bool
 	err      string
@@ -41,6 +61,24 @@ func (r restoreRet) String() string {
 	switch {
 	case r.done:
 		m := "\nRestore successfully finished!\n"
+		if len(r.ReplLag) > 0 {
+			rss := make([]string, 0, len(r.ReplLag))
+			for rs := range r.ReplLag {
+				rss = append(rss, rs)
+			}
+			sort.Strings(rss)
+			m += "Replication lag:\n"
+			for _, rs := range rss {
+				nodes := make([]string, 0, len(r.ReplLag[rs]))
+				for n := range r.ReplLag[rs] {
+					nodes = append(nodes, n)
+				}
+				sort.Strings(nodes)
+				for _, n := range nodes {
+					m += fmt.Sprintf("  %s/%s: %ds\n", rs, n, r.ReplLag[rs][n])
+				}
+			}
+		}
 		if r.physical {
 			m += "Restart the cluster and pbm-agents, and run `pbm config --force-resync`"
 		}
@@ -71,9 +109,53 @@ func runRestore(cn *pbm.PBM, o *restoreOpts, outf outFormat) (fmt.Stringer, erro
 		return nil, errors.New("either a backup name or point in time should be set, non both together!")
 	}
 
+	nss, err := parseRestoreNS(o.dbs, o.colls)
+	if err != nil {
+		return nil, err
+	}
+	if len(nss) > 0 && o.pitr != "" {
+		return nil, errors.New("selective restore is not supported for the point-in-time restore")
+	}
+
+	var maxLag *int
+	var replTimeout int
+	if o.waitRepl {
+		if o.replTimeout < time.Second {
+			return nil, errors.New("--replication-timeout should be at least 1s")
+		}
+		l := int(o.maxLag.Seconds())
+		maxLag = &l
+		replTimeout = int(o.replTimeout.Seconds())
+	}
+
+	if o.preflight {
+		return restorePreflight(cn, o, rsMap, maxLag, nss)
+	}
+
+	// check the name before asking for the confirmation
+	if o.bcp != "" {
+		_, err := cn.GetBackupMeta(o.bcp)
+		if errors.Is(err, pbm.ErrNotFound) {
+			return nil, newBcpNotFoundErr(cn, o.bcp)
+		}
+	}
+
+	if !o.yes {
+		if !isTTY() {
+			return nil, errors.New("no terminal to confirm the restore, run with --yes to proceed without confirmation")
+		}
+		ok, err := confirm(cn.Context(), "Are you sure you want to restore into the cluster? Current data will be overwritten.")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+
 	switch {
 	case o.bcp != "":
-		m, err := restore(cn, o.bcp, rsMap, outf)
+		m, err := restore(cn, o.bcp, rsMap, maxLag, replTimeout, nss, outf)
 		if err != nil {
 			return nil, err
 		}
@@ -85,12 +167,13 @@ func runRestore(cn *pbm.PBM, o *restoreOpts, outf outFormat) (fmt.Stringer, erro
 		if m.Type == pbm.PhysicalBackup {
 			typ = fmt.Sprintf(" physical restore. Leader: %s\nWaiting to finish", m.Leader)
 		}
-		fmt.Printf("Started%s", typ)
+		fmt.Fprintf(progw, "Started%s", typ)
 		err = waitRestore(cn, m)
 		if err == nil {
 			return restoreRet{
 				done:     true,
 				physical: m.Type == pbm.PhysicalBackup,
+				ReplLag:  restoreReplLag(cn, m.Name, maxLag),
 			}, nil
 		}
 
@@ -99,27 +182,341 @@ func runRestore(cn *pbm.PBM, o *restoreOpts, outf outFormat) (fmt.Stringer, erro
 		}
 		return restoreRet{err: fmt.Sprintf("%s.\n Try to check logs on node %s", err.Error(), m.Leader)}, nil
 	case o.pitr != "":
-		m, err := pitrestore(cn, o.pitr, o.pitrBase, rsMap, outf)
+		m, err := pitrestore(cn, o.pitr, o.pitrBase, rsMap, maxLag, replTimeout, outf)
 		if err != nil {
 			return nil, err
 		}
 		if !o.wait || m == nil {
 			return restoreRet{PITR: o.pitr}, nil
 		}
-		fmt.Print("Started.\nWaiting to finish")
+		fmt.Fprint(progw, "Started.\nWaiting to finish")
 		err = waitRestore(cn, m)
 		if err != nil {
 			return restoreRet{err: err.Error()}, nil
 		}
 		return restoreRet{
-			done: true,
-			PITR: o.pitr,
+			done:    true,
+			PITR:    o.pitr,
+			ReplLag: restoreReplLag(cn, m.Name, maxLag),
 		}, nil
 	default:
 		return nil, errors.New("undefined restore state")
 	}
 }
 
+type preflightCheck struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+type preflightOut struct {
+	Backup     string           `json:"backup,omitempty"`
+	Type       pbm.BackupType   `json:"type,omitempty"`
+	Replsets   []string         `json:"replsets,omitempty"`
+	Namespaces []string         `json:"namespaces,omitempty"`
+	Checks     []preflightCheck `json:"checks"`
+}
+
+func (p preflightOut) HasError() bool {
+	for _, c := range p.Checks {
+		if !c.OK {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p preflightOut) String() string {
+	s := fmt.Sprintf("Restore preflight checks for the backup '%s':\n", p.Backup)
+	if len(p.Replsets) > 0 {
+		s += fmt.Sprintf("  %s restore of replsets: %s\n", p.Type, strings.Join(p.Replsets, ", "))
+	}
+	if len(p.Namespaces) > 0 {
+		s += fmt.Sprintf("  namespaces: %s\n", strings.Join(p.Namespaces, ", "))
+	}
+	for _, c := range p.Checks {
+		if c.OK {
+			s += fmt.Sprintf("  [OK]     %s\n", c.Name)
+		} else {
+			s += fmt.Sprintf("  [FAILED] %s: %s\n", c.Name, c.Error)
+		}
+	}
+
+	if p.HasError() {
+		return s + "\nPreflight failed"
+	}
+	return s + "\nPreflight passed"
+}
+
+func (p *preflightOut) add(name string, err error) bool {
+	c := preflightCheck{Name: name, OK: err == nil}
+	if err != nil {
+		c.Error = err.Error()
+	}
+	p.Checks = append(p.Checks, c)
+
+	return c.OK
+}
+
+// restorePreflight runs the checks a restore relies on without starting it.
+// In case of the point-in-time restore, checks are run against the base snapshot.
+func restorePreflight(cn *pbm.PBM, o *restoreOpts, rsMap map[string]string, maxLag *int, nss []string) (preflightOut, error) {
+	out := preflightOut{Backup: o.bcp}
+
+	var ts primitive.Timestamp
+	if o.pitr != "" {
+		var err error
+		ts, err = parseTS(o.pitr)
+		if err != nil {
+			return out, err
+		}
+	}
+
+	var bcp *pbm.BackupMeta
+	var err error
+	switch {
+	case o.bcp != "":
+		bcp, err = cn.GetBackupMeta(o.bcp)
+	case o.pitrBase != "":
+		out.Backup = o.pitrBase
+		bcp, err = cn.GetBackupMeta(o.pitrBase)
+	default:
+		bcp, err = cn.GetLastBackup(&ts)
+	}
+	if errors.Is(err, pbm.ErrNotFound) {
+		err = errors.New("backup not found")
+	}
+	if !out.add("backup metadata", err) {
+		return out, nil
+	}
+	out.Backup = bcp.Name
+	out.Type = bcp.Type
+	mapRS := pbm.MakeRSMapFunc(rsMap)
+	for _, rs := range bcp.Replsets {
+		n := rs.Name
+		if to := mapRS(rs.Name); to != rs.Name {
+			n += " -> " + to
+		}
+		out.Replsets = append(out.Replsets, n)
+	}
+
+	if bcp.Status != pbm.StatusDone {
+		err = errors.Errorf("backup didn't finish successfully, status: %s", bcp.Status)
+	}
+	out.add("backup status", err)
+
+	err = nil
+	if !version.Compatible(version.DefaultInfo.Version, bcp.PBMVersion) {
+		err = errors.Errorf("backup version (v%s) is not compatible with PBM v%s", bcp.PBMVersion, version.DefaultInfo.Version)
+	}
+	out.add("version compatibility", err)
+
+	// the same checks as restore and pitrestore do
+	if o.pitr != "" {
+		out.add("point-in-time range", checkPITRTime(cn, ts, o.pitrBase != "", rsMap))
+	} else {
+		out.add("restore options", checkRestoreOpts(cn, bcp, maxLag, nss))
+	}
+	out.add("cluster topology", checkBcpTopology(cn, *bcp, rsMap))
+	out.add("no concurrent operations", checkConcurrentOp(cn))
+
+	cfg, err := cn.GetConfig()
+	if !out.add("storage config", errors.Wrap(err, "get config")) {
+		return out, nil
+	}
+	out.add("agents storage access", checkAgentsStorage(cn, &cfg.Storage))
+
+	stg, err := cn.GetStorage(cn.Logger().NewEvent("", "", "", primitive.Timestamp{}))
+	if !out.add("storage access", errors.Wrap(err, "get storage")) {
+		return out, nil
+	}
+	if !out.add("backup files on storage", checkBcpFiles(bcp, stg)) || bcp.Type == pbm.PhysicalBackup {
+		return out, nil
+	}
+
+	out.Namespaces, err = bcpNamespaces(bcp, stg, nss)
+	out.add("backup namespaces", err)
+
+	return out, nil
+}
+
+// checkRestoreOpts checks that the backup can be restored with given options.
+// The restore and its preflight both rely on it.
+func checkRestoreOpts(cn *pbm.PBM, bcp *pbm.BackupMeta, maxLag *int, nss []string) error {
+	if maxLag != nil && bcp.Type == pbm.PhysicalBackup {
+		return errors.New("--wait-for-replication is not supported for physical backups")
+	}
+	if len(nss) > 0 {
+		if bcp.Type == pbm.PhysicalBackup {
+			return errors.New("selective restore is not supported for physical backups")
+		}
+		inf, err := cn.GetNodeInfo()
+		if err != nil {
+			return errors.Wrap(err, "define cluster state")
+		}
+		if inf.IsSharded() {
+			return errors.New("selective restore is not supported for sharded clusters")
+		}
+	}
+
+	return nil
+}
+
+// bcpNamespaces returns the logical backup namespaces to be restored.
+// They are read from the dumps' archive prelude, so only the beginning
+// of each dump is downloaded.
+func bcpNamespaces(bcp *pbm.BackupMeta, stg storage.Storage, nss []string) ([]string, error) {
+	var all []string
+	for _, rs := range bcp.Replsets {
+		err := func() error {
+			src, err := stg.SourceReader(rs.DumpName)
+			if err != nil {
+				return errors.Wrapf(err, "get file %s", rs.DumpName)
+			}
+			defer src.Close()
+
+			rdr, err := prestore.Decompress(src, bcp.Compression)
+			if err != nil {
+				return errors.Wrapf(err, "decompress %s", rs.DumpName)
+			}
+			defer rdr.Close()
+
+			var p archive.Prelude
+			err = p.Read(rdr)
+			if err != nil {
+				return errors.Wrapf(err, "read %s", rs.DumpName)
+			}
+			for _, c := range p.NamespaceMetadatas {
+				all = append(all, c.Database+"."+c.Collection)
+			}
+			return nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return matchNamespaces(all, nss)
+}
+
+// matchNamespaces returns sorted unique namespaces matching any of nss
+// or all of them if nss is empty. Each of nss has to match at least
+// one namespace, otherwise nsNotFoundErr is returned.
+func matchNamespaces(all, nss []string) ([]string, error) {
+	set := make(map[string]struct{})
+	var missed []string
+	if len(nss) == 0 {
+		for _, n := range all {
+			set[n] = struct{}{}
+		}
+	}
+	for _, p := range nss {
+		m, err := ns.NewMatcher([]string{p})
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse namespace %s", p)
+		}
+		found := false
+		for _, n := range all {
+			if m.Has(n) {
+				set[n] = struct{}{}
+				found = true
+			}
+		}
+		if !found {
+			missed = append(missed, p)
+		}
+	}
+	if len(missed) > 0 {
+		return nil, nsNotFoundErr(missed)
+	}
+
+	names := make([]string, 0, len(set))
+	for n := range set {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// nsNotFoundErr is returned when requested namespaces don't match
+// anything in the backup.
+type nsNotFoundErr []string
+
+func (e nsNotFoundErr) Error() string {
+	return fmt.Sprintf("no data in the backup for: %s", strings.Join(e, ", "))
+}
+
+func (nsNotFoundErr) Unwrap() error {
+	return pbm.ErrNotFound
+}
+
+func checkBcpTopology(cn *pbm.PBM, bcp pbm.BackupMeta, rsMap map[string]string) error {
+	shards, err := cn.ClusterMembers()
+	if err != nil {
+		return errors.Wrap(err, "get cluster members")
+	}
+	inf, err := cn.GetNodeInfo()
+	if err != nil {
+		return errors.Wrap(err, "define cluster state")
+	}
+
+	sh := make(map[string]struct{}, len(shards))
+	for _, s := range shards {
+		sh[s.RS] = struct{}{}
+	}
+
+	// bcpMatchCluster skips unsuccessful backups, the status is checked on its own
+	bcp.Status, bcp.Error = pbm.StatusDone, ""
+	var buf []string
+	bcpMatchCluster(&bcp, sh, inf.SetName, &buf, pbm.MakeRSMapFunc(rsMap))
+	if bcp.Status == pbm.StatusError {
+		return errors.New(bcp.Error)
+	}
+
+	return nil
+}
+
+// checkBcpFiles checks that all backup files are present on the storage
+func checkBcpFiles(bcp *pbm.BackupMeta, stg storage.Storage) error {
+	var files []string
+	for _, rs := range bcp.Replsets {
+		files = append(files, bcpRSFiles(bcp, rs)...)
+	}
+
+	var missed []string
+	for _, f := range files {
+		_, err := stg.FileStat(f)
+		if err == storage.ErrNotExist {
+			missed = append(missed, f)
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "get file %s", f)
+		}
+	}
+	if len(missed) > 0 {
+		return errors.Errorf("missing files: %s", strings.Join(missed, ", "))
+	}
+
+	return nil
+}
+
+// bcpRSFiles returns storage paths of the replset's backup files
+func bcpRSFiles(bcp *pbm.BackupMeta, rs pbm.BackupReplset) []string {
+	if bcp.Type != pbm.PhysicalBackup {
+		return []string{rs.DumpName, rs.OplogName}
+	}
+
+	files := make([]string, 0, len(rs.Files))
+	for _, f := range rs.Files {
+		files = append(files, filepath.Join(bcp.Name, rs.Name, f.Name+bcp.Compression.Suffix()))
+	}
+	return files
+}
+
 func waitRestore(cn *pbm.PBM, m *pbm.RestoreMeta) error {
 	ep, _ := cn.GetEpoch()
 	stg, err := cn.GetStorage(cn.Logger().NewEvent(string(pbm.CmdRestore), m.Backup, m.OPID, ep.TS()))
@@ -142,7 +539,7 @@ func waitRestore(cn *pbm.PBM, m *pbm.RestoreMeta) error {
 	}
 
 	for range tk.C {
-		fmt.Print(".")
+		fmt.Fprint(progw, ".")
 		rmeta, err = getMeta(fname)
 		if errors.Is(err, pbm.ErrNotFound) {
 			continue
@@ -172,6 +569,26 @@ func waitRestore(cn *pbm.PBM, m *pbm.RestoreMeta) error {
 	return nil
 }
 
+// restoreReplLag returns the replication lag of secondaries saved by agents
+// at the end of the restore. It's nil if the restore didn't wait for the replication.
+func restoreReplLag(cn *pbm.PBM, name string, maxLag *int) map[string]map[string]int {
+	if maxLag == nil {
+		return nil
+	}
+	m, err := cn.GetRestoreMeta(name)
+	if err != nil {
+		return nil
+	}
+
+	lag := make(map[string]map[string]int)
+	for _, rs := range m.Replsets {
+		if rs.ReplLag != nil {
+			lag[rs.Name] = rs.ReplLag
+		}
+	}
+	return lag
+}
+
 func getRestoreMetaStg(name string, stg storage.Storage) (*pbm.RestoreMeta, error) {
 	_, err := stg.FileStat(name)
 	if err == storage.ErrNotExist {
@@ -203,10 +620,10 @@ func (e errRestoreFailed) Error() string {
 	return e.string
 }
 
-func restore(cn *pbm.PBM, bcpName string, rsMapping map[string]string, outf outFormat) (*pbm.RestoreMeta, error) {
+func restore(cn *pbm.PBM, bcpName string, rsMapping map[string]string, maxLag *int, replTimeout int, nss []string, outf outFormat) (*pbm.RestoreMeta, error) {
 	bcp, err := cn.GetBackupMeta(bcpName)
 	if errors.Is(err, pbm.ErrNotFound) {
-		return nil, errors.Errorf("backup '%s' not found", bcpName)
+		return nil, newBcpNotFoundErr(cn, bcpName)
 	}
 	if err != nil {
 		return nil, errors.Wrap(err, "get backup data")
@@ -214,6 +631,20 @@ func restore(cn *pbm.PBM, bcpName string, rsMapping map[string]string, outf outF
 	if bcp.Status != pbm.StatusDone {
 		return nil, errors.Errorf("backup '%s' didn't finish successfully", bcpName)
 	}
+	err = checkRestoreOpts(cn, bcp, maxLag, nss)
+	if err != nil {
+		return nil, err
+	}
+	if len(nss) > 0 {
+		stg, err := cn.GetStorage(cn.Logger().NewEvent("", "", "", primitive.Timestamp{}))
+		if err != nil {
+			return nil, errors.Wrap(err, "get storage")
+		}
+		_, err = bcpNamespaces(bcp, stg, nss)
+		if err != nil {
+			return nil, errors.WithMessage(err, "check namespaces")
+		}
+	}
 
 	err = checkConcurrentOp(cn)
 	if err != nil {
@@ -224,9 +655,12 @@ func restore(cn *pbm.PBM, bcpName string, rsMapping map[string]string, outf outF
 	err = cn.SendCmd(pbm.Cmd{
 		Cmd: pbm.CmdRestore,
 		Restore: pbm.RestoreCmd{
-			Name:       name,
-			BackupName: bcpName,
-			RSMap:      rsMapping,
+			Name:            name,
+			BackupName:      bcpName,
+			RSMap:           rsMapping,
+			MaxReplLag:      maxLag,
+			ReplWaitTimeout: replTimeout,
+			Namespaces:      nss,
 		},
 	})
 	if err != nil {
@@ -240,7 +674,10 @@ func restore(cn *pbm.PBM, bcpName string, rsMapping map[string]string, outf outF
 		}, nil
 	}
 
-	fmt.Printf("Starting restore from '%s'", bcpName)
+	if len(nss) > 0 {
+		fmt.Fprintln(os.Stderr, "WARNING: selective restore doesn't restore users and roles, the current ones are kept")
+	}
+	fmt.Fprintf(progw, "Starting restore from '%s'", bcpName)
 
 	ctx, cancel := context.WithTimeout(context.Background(), pbm.WaitActionStart)
 	defer cancel()
@@ -248,6 +685,49 @@ func restore(cn *pbm.PBM, bcpName string, rsMapping map[string]string, outf outF
 	return waitForRestoreStatus(ctx, cn, name)
 }
 
+// checkPITRTime checks if the time falls within the available PITR ranges.
+// Ranges without a base snapshot are taken into account only if the base
+// snapshot is set explicitly.
+func checkPITRTime(cn *pbm.PBM, ts primitive.Timestamp, unbacked bool, rsMap map[string]string) error {
+	ranges, _, err := getPitrList(cn, 0, false, unbacked, rsMap)
+	if err != nil {
+		return errors.Wrap(err, "get PITR ranges")
+	}
+
+	var rs []string
+	for _, r := range ranges {
+		if ts.T >= r.Range.Start && ts.T <= r.Range.End {
+			return nil
+		}
+		rs = append(rs, r.Range.String())
+	}
+
+	if len(rs) == 0 {
+		return errors.Errorf("no PITR data for %s", fmtTS(int64(ts.T)))
+	}
+	return errors.Errorf("no PITR data for %s, available ranges:\n  %s", fmtTS(int64(ts.T)), strings.Join(rs, "\n  "))
+}
+
+// parseRestoreNS returns namespaces to restore for given databases and
+// collections (in form <db>.<collection>)
+func parseRestoreNS(dbs, colls []string) ([]string, error) {
+	var nss []string
+	for _, d := range dbs {
+		if d == "" || strings.Contains(d, ".") {
+			return nil, errors.Errorf("invalid database name %q", d)
+		}
+		nss = append(nss, d+".*")
+	}
+	for _, c := range colls {
+		if d := strings.SplitN(c, ".", 2); len(d) != 2 || d[0] == "" || d[1] == "" {
+			return nil, errors.Errorf("invalid collection %q, should be in form <db>.<collection>", c)
+		}
+		nss = append(nss, c)
+	}
+
+	return nss, nil
+}
+
 func parseTS(t string) (ts primitive.Timestamp, err error) {
 	if si := strings.SplitN(t, ",", 2); len(si) == 2 {
 		tt, err := strconv.ParseInt(si[0], 10, 64)
@@ -270,12 +750,17 @@ func parseTS(t string) (ts primitive.Timestamp, err error) {
 	return primitive.Timestamp{T: uint32(tsto.Unix()), I: 0}, nil
 }
 
-func pitrestore(cn *pbm.PBM, t, base string, rsMap map[string]string, outf outFormat) (rmeta *pbm.RestoreMeta, err error) {
+func pitrestore(cn *pbm.PBM, t, base string, rsMap map[string]string, maxLag *int, replTimeout int, outf outFormat) (rmeta *pbm.RestoreMeta, err error) {
 	ts, err := parseTS(t)
 	if err != nil {
 		return nil, err
 	}
 
+	err = checkPITRTime(cn, ts, base != "", rsMap)
+	if err != nil {
+		return nil, err
+	}
+
 	err = checkConcurrentOp(cn)
 	if err != nil {
 		return nil, err
@@ -285,11 +770,13 @@ func pitrestore(cn *pbm.PBM, t, base string, rsMap map[string]string, outf outFo
 	err = cn.SendCmd(pbm.Cmd{
 		Cmd: pbm.CmdPITRestore,
 		PITRestore: pbm.PITRestoreCmd{
-			Name:  name,
-			TS:    int64(ts.T),
-			I:     int64(ts.I),
-			Bcp:   base,
-			RSMap: rsMap,
+			Name:            name,
+			TS:              int64(ts.T),
+			I:               int64(ts.I),
+			Bcp:             base,
+			RSMap:           rsMap,
+			MaxReplLag:      maxLag,
+			ReplWaitTimeout: replTimeout,
 		},
 	})
 	if err != nil {
@@ -300,7 +787,7 @@ func pitrestore(cn *pbm.PBM, t, base string, rsMap map[string]string, outf outFo
 		return nil, nil
 	}
 
-	fmt.Printf("Starting restore to the point in time '%s'", t)
+	fmt.Fprintf(progw, "Starting restore to the point in time '%s'", t)
 
 	ctx, cancel := context.WithTimeout(context.Background(), pbm.WaitActionStart)
 	defer cancel()
@@ -316,7 +803,7 @@ func waitForRestoreStatus(ctx context.Context, cn *pbm.PBM, name string) (*pbm.R
 	for {
 		select {
 		case <-tk.C:
-			fmt.Print(".")
+			fmt.Fprint(progw, ".")
 			meta, err = cn.GetRestoreMeta(name)
 			if errors.Is(err, pbm.ErrNotFound) {
 				continue
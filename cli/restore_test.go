@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/percona/percona-backup-mongodb/pbm"
+)
+
+func TestParseRestoreNS(t *testing.T) {
+	cases := []struct {
+		dbs   []string
+		colls []string
+		want  []string
+		err   bool
+	}{
+		{nil, nil, nil, false},
+		{[]string{"db1"}, nil, []string{"db1.*"}, false},
+		{[]string{"db1", "db2"}, []string{"db3.c", "db4.c.sub"}, []string{"db1.*", "db2.*", "db3.c", "db4.c.sub"}, false},
+		{[]string{""}, nil, nil, true},
+		{[]string{"db1.c"}, nil, nil, true},
+		{nil, []string{"db1"}, nil, true},
+		{nil, []string{"db1."}, nil, true},
+		{nil, []string{".c"}, nil, true},
+	}
+	for _, c := range cases {
+		got, err := parseRestoreNS(c.dbs, c.colls)
+		if (err != nil) != c.err {
+			t.Errorf("%v %v: expected error %v, got %v", c.dbs, c.colls, c.err, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v %v: expected %v, got %v", c.dbs, c.colls, c.want, got)
+		}
+	}
+}
+
+func TestMatchNamespaces(t *testing.T) {
+	all := []string{"db1.c1", "db1.c2", "db2.c1", "db1.c1"}
+	cases := []struct {
+		nss  []string
+		want []string
+		miss nsNotFoundErr
+	}{
+		{nil, []string{"db1.c1", "db1.c2", "db2.c1"}, nil},
+		{[]string{"db1.*"}, []string{"db1.c1", "db1.c2"}, nil},
+		{[]string{"db1.*", "db2.c1"}, []string{"db1.c1", "db1.c2", "db2.c1"}, nil},
+		{[]string{"db3.*"}, nil, nsNotFoundErr{"db3.*"}},
+		{[]string{"db1.c1", "db2.c2", "db4.*"}, nil, nsNotFoundErr{"db2.c2", "db4.*"}},
+	}
+	for _, c := range cases {
+		got, err := matchNamespaces(all, c.nss)
+		if c.miss != nil {
+			var e nsNotFoundErr
+			if !errors.As(err, &e) || !reflect.DeepEqual(e, c.miss) {
+				t.Errorf("%v: expected missed %v, got %v", c.nss, c.miss, err)
+			}
+			if !errors.Is(err, pbm.ErrNotFound) {
+				t.Errorf("%v: expected ErrNotFound, got %v", c.nss, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", c.nss, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: expected %v, got %v", c.nss, c.want, got)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -316,7 +317,7 @@ func getPitrErr(cn *pbm.PBM) (string, error) {
 
 	shards, err := cn.ClusterMembers()
 	if err != nil {
-		log.Fatalf("Error: get cluster members: %v", err)
+		return "", errors.Wrap(err, "get cluster members")
 	}
 
 	var errs []string
@@ -695,3 +696,178 @@ func getPhysSnapshotSize(bcp *pbm.BackupMeta, stg storage.Storage) (s int64, err
 
 	return s, nil
 }
+
+type healthOut struct {
+	Checks []preflightCheck `json:"checks"`
+}
+
+func (h healthOut) HasError() bool {
+	for _, c := range h.Checks {
+		if !c.OK {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h healthOut) String() string {
+	s := ""
+	for _, c := range h.Checks {
+		if c.OK {
+			s += fmt.Sprintf("[OK]     %s\n", c.Name)
+		} else {
+			s += fmt.Sprintf("[FAILED] %s: %s\n", c.Name, c.Error)
+		}
+	}
+
+	if h.HasError() {
+		return s + "\nUnhealthy"
+	}
+	return s + "\nHealthy"
+}
+
+// health checks that every cluster node has a live agent
+// and that agents can access the storage
+func health(cn *pbm.PBM, curi string) (fmt.Stringer, error) {
+	var out healthOut
+
+	c, err := clusterStatus(cn, curi)
+	if !addCheck(&out.Checks, "cluster connection", err) {
+		return out, nil
+	}
+	for _, rs := range c.(cluster) {
+		for _, n := range rs.Nodes {
+			var err error
+			switch {
+			case n.OK:
+			case len(n.Errs) > 0:
+				err = errors.New(strings.Join(n.Errs, "; "))
+			default:
+				err = errors.Errorf("agent %s", strings.ToLower(n.Ver))
+			}
+			addCheck(&out.Checks, "agent "+n.Host, err)
+		}
+	}
+
+	cfg, err := cn.GetConfig()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = errors.New("storage is not set")
+	}
+	if !addCheck(&out.Checks, "storage config", errors.Wrap(err, "get config")) {
+		return out, nil
+	}
+	addCheck(&out.Checks, "agents storage access", checkAgentsStorage(cn, &cfg.Storage))
+
+	return out, nil
+}
+
+type storageCheckOut struct {
+	Storage string           `json:"storage"`
+	Checks  []preflightCheck `json:"checks"`
+}
+
+func (s storageCheckOut) HasError() bool {
+	return healthOut{Checks: s.Checks}.HasError()
+}
+
+func (s storageCheckOut) String() string {
+	r := fmt.Sprintf("Storage: %s\n", s.Storage)
+	for _, c := range s.Checks {
+		if c.OK {
+			r += fmt.Sprintf("[OK]     %s\n", c.Name)
+		} else {
+			r += fmt.Sprintf("[FAILED] %s: %s\n", c.Name, c.Error)
+		}
+	}
+
+	return r
+}
+
+// checkStorage makes every live agent write and delete a probe file
+// on the storage and reports the result of each one
+func checkStorage(cn *pbm.PBM, outf outFormat) (fmt.Stringer, error) {
+	cfg, err := cn.GetConfig()
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("storage is not set")
+		}
+		return nil, errors.Wrap(err, "get config")
+	}
+
+	agents, err := cn.AgentsStatus()
+	if err != nil {
+		return nil, errors.Wrap(err, "get agents status")
+	}
+	ts, err := cn.ClusterTime()
+	if err != nil {
+		return nil, errors.Wrap(err, "read cluster time")
+	}
+	// only live agents can respond
+	var wait []string
+	for _, a := range agents {
+		if a.Heartbeat.T+pbm.StaleFrameSec >= ts.T {
+			wait = append(wait, a.RS+"/"+a.Node)
+		}
+	}
+	if len(wait) == 0 {
+		return nil, errors.New("no live agents found")
+	}
+	sort.Strings(wait)
+
+	tsop := time.Now().UTC()
+	err = cn.SendCmd(pbm.Cmd{Cmd: pbm.CmdCheckStorage})
+	if err != nil {
+		return nil, errors.Wrap(err, "send command")
+	}
+	if outf == outText {
+		fmt.Fprint(progw, "Waiting for agents to check the storage ")
+	}
+
+	res := make(map[string]error)
+	for i := 0; i < 30 && len(res) < len(wait); i++ {
+		time.Sleep(time.Second)
+		if outf == outText {
+			fmt.Fprint(progw, ".")
+		}
+
+		l, err := cn.LogGet(
+			&plog.LogRequest{
+				TimeMin: tsop,
+				LogKeys: plog.LogKeys{
+					Severity: plog.Info,
+					Event:    string(pbm.CmdCheckStorage),
+				},
+			}, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "read agents log")
+		}
+		// entries are newest first, so the first one of a node is its latest check
+		res = make(map[string]error)
+		for _, e := range l.Data {
+			n := e.RS + "/" + e.Node
+			if _, ok := res[n]; ok {
+				continue
+			}
+			var err error
+			if e.Severity != plog.Info {
+				err = errors.New(e.Msg)
+			}
+			res[n] = err
+		}
+	}
+	if outf == outText {
+		fmt.Fprintln(progw)
+	}
+
+	out := storageCheckOut{Storage: fmt.Sprintf("%s %s", cfg.Storage.Typ(), cfg.Storage.Path())}
+	for _, n := range wait {
+		err, ok := res[n]
+		if !ok {
+			err = errors.New("no response from the agent")
+		}
+		addCheck(&out.Checks, n, err)
+	}
+
+	return out, nil
+}
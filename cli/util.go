@@ -1,7 +1,12 @@
 package cli
 
 import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -48,3 +53,96 @@ func makeStrSet() func(string) bool {
 		return false
 	}
 }
+
+// expandPath expands the leading `~` to the user's home directory
+// and environment variables like $HOME in the given path
+func expandPath(p string) (string, error) {
+	p = os.ExpandEnv(p)
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return p, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "get user home dir")
+	}
+
+	return filepath.Join(home, p[1:]), nil
+}
+
+// parseTimeBound parses the time given either in RFC3339, datetimeFormat,
+// dateFormat or relatively to now as a duration (e.g. 12h, 7d)
+func parseTimeBound(v string, now time.Time) (time.Time, error) {
+	if strings.HasSuffix(v, "d") {
+		d, err := strconv.Atoi(strings.TrimSuffix(v, "d"))
+		if err == nil {
+			return now.AddDate(0, 0, -d), nil
+		}
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+
+	return parseDateT(v)
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// closeNames returns names within a few edits from the given one,
+// closest first
+func closeNames(name string, names []string) []string {
+	const maxDist = 3
+
+	type cand struct {
+		name string
+		dist int
+	}
+	var cc []cand
+	for _, n := range names {
+		if d := levenshtein(name, n); d <= maxDist {
+			cc = append(cc, cand{n, d})
+		}
+	}
+	sort.SliceStable(cc, func(i, j int) bool { return cc[i].dist < cc[j].dist })
+
+	rv := make([]string, 0, len(cc))
+	for _, c := range cc {
+		rv = append(rv, c.name)
+	}
+	return rv
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExpandPath(t *testing.T) {
+	home, err := ioutil.TempDir("", "pbm-home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+
+	cfile := filepath.Join(home, "pbm.yaml")
+	err = ioutil.WriteFile(cfile, []byte("pitr:\n  enabled: false\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := os.Getenv("HOME")
+	os.Setenv("HOME", home)
+	defer os.Setenv("HOME", old)
+
+	cases := map[string]string{
+		"~":                home,
+		"~/pbm.yaml":       cfile,
+		"$HOME/pbm.yaml":   cfile,
+		"${HOME}/pbm.yaml": cfile,
+		"/etc/pbm.yaml":    "/etc/pbm.yaml",
+		"~pbm.yaml":        "~pbm.yaml",
+	}
+	for in, want := range cases {
+		got, err := expandPath(in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: expected %s, got %s", in, want, got)
+		}
+	}
+
+	p, err := expandPath("~/pbm.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ioutil.ReadFile(p); err != nil {
+		t.Errorf("config file should be located: %v", err)
+	}
+}
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2021, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]time.Time{
+		"7d":                   time.Date(2021, 6, 8, 12, 0, 0, 0, time.UTC),
+		"12h":                  time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC),
+		"2021-06-01T10:00:00Z": time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC),
+		"2021-06-01T10:00:00":  time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC),
+		"2021-06-01":           time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for in, want := range cases {
+		got, err := parseTimeBound(in, now)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("%s: expected %v, got %v", in, want, got)
+		}
+	}
+
+	if _, err := parseTimeBound("yesterday", now); err == nil {
+		t.Error("expected error for invalid time")
+	}
+}
+
+func TestCloseNames(t *testing.T) {
+	names := []string{
+		"2022-01-02T15:04:05Z",
+		"2022-01-02T15:04:15Z",
+		"2022-01-03T10:00:00Z",
+	}
+
+	cases := []struct {
+		name string
+		want []string
+	}{
+		{"2022-01-02T15:04:05Z", []string{"2022-01-02T15:04:05Z", "2022-01-02T15:04:15Z"}},
+		{"2022-01-02T15:04:15", []string{"2022-01-02T15:04:15Z", "2022-01-02T15:04:05Z"}},
+		{"2022-01-03T10:00:00", []string{"2022-01-03T10:00:00Z"}},
+		{"backup", []string{}},
+	}
+
+	for _, c := range cases {
+		got := closeNames(c.name, names)
+		if len(got) != len(c.want) {
+			t.Errorf("closeNames(%q) = %v, want %v", c.name, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("closeNames(%q) = %v, want %v", c.name, got, c.want)
+				break
+			}
+		}
+	}
+}
@@ -266,7 +266,7 @@ func (c *Ctl) waitForRestore(rinlist string, waitFor time.Duration) error {
 
 // Restore starts restore and returns the name of op
 func (c *Ctl) Restore(bcpName string) (string, error) {
-	o, err := c.RunCmd("pbm", "restore", bcpName, "-o", "json")
+	o, err := c.RunCmd("pbm", "restore", bcpName, "--yes", "-o", "json")
 	if err != nil {
 		return "", errors.Wrap(err, "run meta")
 	}
@@ -292,12 +292,12 @@ func (c *Ctl) ReplayOplog(a, b time.Time) error {
 }
 
 func (c *Ctl) PITRestore(t time.Time) error {
-	_, err := c.RunCmd("pbm", "restore", "--time", t.Format("2006-01-02T15:04:05"))
+	_, err := c.RunCmd("pbm", "restore", "--yes", "--time", t.Format("2006-01-02T15:04:05"))
 	return err
 }
 
 func (c *Ctl) PITRestoreClusterTime(t, i uint32) error {
-	_, err := c.RunCmd("pbm", "restore", "--time", fmt.Sprintf("%d,%d", t, i))
+	_, err := c.RunCmd("pbm", "restore", "--yes", "--time", fmt.Sprintf("%d,%d", t, i))
 	return err
 }
 
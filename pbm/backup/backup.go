@@ -68,6 +68,7 @@ func (b *Backup) Init(bcp pbm.BackupCmd, opid pbm.OPID, balancer pbm.BalancerMod
 		Nomination:     []pbm.BackupRsNomination{},
 		BalancerStatus: balancer,
 		Hb:             ts,
+		Labels:         bcp.Labels,
 	}
 
 	cfg, err := b.cn.GetConfig()
@@ -106,6 +107,7 @@ func (b *Backup) Run(ctx context.Context, bcp pbm.BackupCmd, opid pbm.OPID, l *p
 	if err != nil {
 		return errors.Wrap(err, "unable to get PBM storage configuration settings")
 	}
+	stg = throttle(stg, bcp.MaxUploadMbps)
 
 	bcpm, err := b.cn.GetBackupMeta(bcp.Name)
 	if err != nil {
@@ -325,10 +327,10 @@ type Canceller interface {
 var ErrCancelled = errors.New("backup canceled")
 
 // Upload writes data to dst from given src and returns an amount of written bytes
-func Upload(ctx context.Context, src Source, dst storage.Storage, compression pbm.CompressionType, compressLevel *int, fname string, sizeb int) (int64, error) {
+func Upload(ctx context.Context, src Source, dst storage.Storage, compression pbm.CompressionType, compressLevel *int, compressThreads int, fname string, sizeb int) (int64, error) {
 	r, pw := io.Pipe()
 
-	w, err := Compress(pw, compression, compressLevel)
+	w, err := Compress(pw, compression, compressLevel, compressThreads)
 	if err != nil {
 		return 0, err
 	}
@@ -565,10 +567,11 @@ func (b *Backup) dumpClusterMeta(bcpName string, stg storage.Storage) error {
 		return errors.Wrap(err, "get backup metadata")
 	}
 
-	return writeMeta(stg, meta)
+	return WriteMeta(stg, meta)
 }
 
-func writeMeta(stg storage.Storage, meta *pbm.BackupMeta) error {
+// WriteMeta saves the backup metadata file on the storage
+func WriteMeta(stg storage.Storage, meta *pbm.BackupMeta) error {
 	b, err := json.MarshalIndent(meta, "", "\t")
 	if err != nil {
 		return errors.Wrap(err, "marshal data")
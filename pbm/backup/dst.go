@@ -24,8 +24,10 @@ type NopCloser struct {
 // Close to satisfy io.WriteCloser interface
 func (NopCloser) Close() error { return nil }
 
-// Compress makes a compressed writer from the given one
-func Compress(w io.Writer, compression pbm.CompressionType, level *int) (io.WriteCloser, error) {
+// Compress makes a compressed writer from the given one.
+// threads sets the concurrency of pgzip, s2 and zstd compressors,
+// the default one is used if it is 0.
+func Compress(w io.Writer, compression pbm.CompressionType, level *int, threads int) (io.WriteCloser, error) {
 	switch compression {
 	case pbm.CompressionTypeGZIP:
 		if level == nil {
@@ -45,6 +47,9 @@ func Compress(w io.Writer, compression pbm.CompressionType, level *int) (io.Writ
 			return nil, err
 		}
 		cc := runtime.NumCPU() / 2
+		if threads > 0 {
+			cc = threads
+		}
 		if cc == 0 {
 			cc = 1
 		}
@@ -63,6 +68,9 @@ func Compress(w io.Writer, compression pbm.CompressionType, level *int) (io.Writ
 		return snappy.NewBufferedWriter(w), nil
 	case pbm.CompressionTypeS2:
 		cc := runtime.NumCPU() / 3
+		if threads > 0 {
+			cc = threads
+		}
 		if cc == 0 {
 			cc = 1
 		}
@@ -83,7 +91,11 @@ func Compress(w io.Writer, compression pbm.CompressionType, level *int) (io.Writ
 		if level != nil {
 			encLevel = zstd.EncoderLevelFromZstd(*level)
 		}
-		return zstd.NewWriter(w, zstd.WithEncoderLevel(encLevel))
+		zopts := []zstd.EOption{zstd.WithEncoderLevel(encLevel)}
+		if threads > 0 {
+			zopts = append(zopts, zstd.WithEncoderConcurrency(threads))
+		}
+		return zstd.NewWriter(w, zopts...)
 	default:
 		return NopCloser{w}, nil
 	}
@@ -89,11 +89,15 @@ func (b *Backup) doLogical(ctx context.Context, bcp pbm.BackupCmd, opid pbm.OPID
 		sz *= 4
 	}
 
-	dump, err := newDump(b.node.ConnURI(), b.node.DumpConns())
+	conns := b.node.DumpConns()
+	if bcp.NumParallelCollections > 0 {
+		conns = bcp.NumParallelCollections
+	}
+	dump, err := newDump(b.node.ConnURI(), conns)
 	if err != nil {
 		return errors.Wrap(err, "init mongodump options")
 	}
-	_, err = Upload(ctx, dump, stg, bcp.Compression, bcp.CompressionLevel, rsMeta.DumpName, sz)
+	_, err = Upload(ctx, dump, stg, bcp.Compression, bcp.CompressionLevel, bcp.CompressionThreads, rsMeta.DumpName, sz)
 	if err != nil {
 		return errors.Wrap(err, "mongodump")
 	}
@@ -139,7 +143,7 @@ func (b *Backup) doLogical(ctx context.Context, bcp pbm.BackupCmd, opid pbm.OPID
 	l.Debug("set oplog span to %v / %v", fwTS, lwTS)
 	oplog.SetTailingSpan(fwTS, lwTS)
 	// size -1 - we're assuming oplog never exceed 97Gb (see comments in s3.Save method)
-	_, err = Upload(ctx, oplog, stg, bcp.Compression, bcp.CompressionLevel, rsMeta.OplogName, -1)
+	_, err = Upload(ctx, oplog, stg, bcp.Compression, bcp.CompressionLevel, bcp.CompressionThreads, rsMeta.OplogName, -1)
 	if err != nil {
 		return errors.Wrap(err, "oplog")
 	}
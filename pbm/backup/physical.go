@@ -226,7 +226,7 @@ func (b *Backup) doPhysical(ctx context.Context, bcp pbm.BackupCmd, opid pbm.OPI
 		default:
 		}
 
-		f, err := writeFile(ctx, bd.Name, subdir+"/"+strings.TrimPrefix(bd.Name, bcur.Meta.DBpath+"/"), stg, bcp.Compression, bcp.CompressionLevel, l)
+		f, err := writeFileRetry(ctx, bd.Name, subdir+"/"+strings.TrimPrefix(bd.Name, bcur.Meta.DBpath+"/"), stg, &bcp, l)
 		if err != nil {
 			return errors.Wrapf(err, "upload file `%s`", bd.Name)
 		}
@@ -272,7 +272,25 @@ func (id *UUID) IsZero() bool {
 	return bytes.Equal(id.UUID[:], uuid.Nil[:])
 }
 
-func writeFile(ctx context.Context, src, dst string, stg storage.Storage, compression pbm.CompressionType, compressLevel *int, l *plog.Event) (*pbm.File, error) {
+// writeFileRetry uploads the file retrying up to bcp.UploadRetries times
+// on failure. Unlike logical dumps, data files can be re-read from the start.
+func writeFileRetry(ctx context.Context, src, dst string, stg storage.Storage, bcp *pbm.BackupCmd, l *plog.Event) (*pbm.File, error) {
+	for i := 0; ; i++ {
+		f, err := writeFile(ctx, src, dst, stg, bcp.Compression, bcp.CompressionLevel, bcp.CompressionThreads, l)
+		if err == nil || i >= bcp.UploadRetries {
+			return f, err
+		}
+
+		l.Warning("upload `%s`, attempt %d/%d: %v", src, i+1, bcp.UploadRetries+1, err)
+		select {
+		case <-ctx.Done():
+			return nil, ErrCancelled
+		case <-time.After(time.Second * time.Duration(i+1)):
+		}
+	}
+}
+
+func writeFile(ctx context.Context, src, dst string, stg storage.Storage, compression pbm.CompressionType, compressLevel *int, compressThreads int, l *plog.Event) (*pbm.File, error) {
 	fstat, err := os.Stat(src)
 	if err != nil {
 		return nil, errors.Wrap(err, "get file stat")
@@ -282,7 +300,7 @@ func writeFile(ctx context.Context, src, dst string, stg storage.Storage, compre
 
 	dst += compression.Suffix()
 
-	_, err = Upload(ctx, &file{src}, stg, compression, compressLevel, dst, int(fstat.Size()))
+	_, err = Upload(ctx, &file{src}, stg, compression, compressLevel, compressThreads, dst, int(fstat.Size()))
 	if err != nil {
 		return nil, errors.Wrap(err, "upload file")
 	}
@@ -0,0 +1,66 @@
+package backup
+
+import (
+	"io"
+	"time"
+
+	"github.com/percona/percona-backup-mongodb/pbm/storage"
+)
+
+// throttledStorage limits the rate of data uploaded to the storage
+type throttledStorage struct {
+	storage.Storage
+	rate int64 // bytes per second
+}
+
+// throttle returns the storage with uploads limited to mbps megabits
+// per second. The storage is returned as is if mbps isn't positive.
+func throttle(stg storage.Storage, mbps int) storage.Storage {
+	if mbps <= 0 {
+		return stg
+	}
+
+	return throttledStorage{Storage: stg, rate: int64(mbps) * 1000 * 1000 / 8}
+}
+
+func (t throttledStorage) Save(name string, data io.Reader, size int) error {
+	return t.Storage.Save(name, &throttledReader{r: data, rate: t.rate}, size)
+}
+
+type throttledReader struct {
+	r     io.Reader
+	rate  int64
+	start time.Time
+	n     int64
+
+	// now and sleep are time.Now and time.Sleep if not set
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if t.now == nil {
+		t.now = time.Now
+	}
+	if t.sleep == nil {
+		t.sleep = time.Sleep
+	}
+	if t.start.IsZero() {
+		t.start = t.now()
+	}
+	// read no more than a second worth of data at once
+	// so the rate stays even
+	if int64(len(p)) > t.rate {
+		p = p[:t.rate]
+	}
+
+	n, err := t.r.Read(p)
+	t.n += int64(n)
+
+	due := time.Duration(float64(t.n) / float64(t.rate) * float64(time.Second))
+	if d := due - t.now().Sub(t.start); d > 0 {
+		t.sleep(d)
+	}
+
+	return n, err
+}
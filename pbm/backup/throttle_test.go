@@ -0,0 +1,89 @@
+package backup
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/percona/percona-backup-mongodb/pbm/storage/blackhole"
+)
+
+// fakeClock is the time source for throttledReader where sleep
+// just moves the time forward
+type fakeClock struct {
+	t     time.Time
+	slept time.Duration
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.t = c.t.Add(d)
+	c.slept += d
+}
+
+func TestThrottledReader(t *testing.T) {
+	const rate = 100 * 1000 // bytes per second
+	data := make([]byte, rate/2)
+
+	c := &fakeClock{t: time.Unix(0, 0)}
+	r := &throttledReader{r: bytes.NewReader(data), rate: rate, now: c.now, sleep: c.sleep}
+	n, err := io.Copy(ioutil.Discard, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(data)) {
+		t.Errorf("expected %d bytes read, got %d", len(data), n)
+	}
+	if c.slept != 500*time.Millisecond {
+		t.Errorf("expected 500ms delay to read %d bytes at %d B/s, got %v", len(data), rate, c.slept)
+	}
+}
+
+func TestThrottledReaderElapsed(t *testing.T) {
+	c := &fakeClock{t: time.Unix(0, 0)}
+	r := &throttledReader{r: bytes.NewReader(make([]byte, 100)), rate: 100, now: c.now, sleep: c.sleep}
+	p := make([]byte, 50)
+
+	if _, err := r.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	// the time spent in between reads counts towards the rate
+	c.t = c.t.Add(2 * time.Second)
+	if _, err := r.Read(p); err != nil {
+		t.Fatal(err)
+	}
+	if c.slept != 500*time.Millisecond {
+		t.Errorf("expected only the first read delayed by 500ms, got %v", c.slept)
+	}
+}
+
+func TestThrottledReaderChunk(t *testing.T) {
+	c := &fakeClock{t: time.Unix(0, 0)}
+	r := &throttledReader{r: bytes.NewReader(make([]byte, 100)), rate: 10, now: c.now, sleep: c.sleep}
+	p := make([]byte, 100)
+	n, err := r.Read(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n > 10 {
+		t.Errorf("expected no more than a second worth of data (10 bytes) at once, got %d", n)
+	}
+	if c.slept != time.Second {
+		t.Errorf("expected 1s delay for %d bytes at 10 B/s, got %v", n, c.slept)
+	}
+}
+
+func TestThrottleNoLimit(t *testing.T) {
+	stg := blackhole.New()
+	for _, mbps := range []int{0, -1} {
+		if _, ok := throttle(stg, mbps).(throttledStorage); ok {
+			t.Errorf("%d: expected the storage as is", mbps)
+		}
+	}
+	if _, ok := throttle(stg, 1).(throttledStorage); !ok {
+		t.Error("expected the throttled storage")
+	}
+}
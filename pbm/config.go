@@ -3,6 +3,7 @@ package pbm
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
@@ -142,6 +143,10 @@ type RestoreConf struct {
 
 type BackupConf struct {
 	Priority map[string]float64 `bson:"priority,omitempty" json:"priority,omitempty" yaml:"priority,omitempty"`
+	// Compression and CompressionLevel are defaults for backups
+	// made without an explicit compression set
+	Compression      CompressionType `bson:"compression,omitempty" json:"compression,omitempty" yaml:"compression,omitempty"`
+	CompressionLevel *int            `bson:"compressionLevel,omitempty" json:"compressionLevel,omitempty" yaml:"compressionLevel,omitempty"`
 }
 
 type confMap map[string]reflect.Kind
@@ -174,6 +179,42 @@ func keys(t reflect.Type) confMap {
 	return v
 }
 
+// setConfField sets the field of the config struct v by the key path
+// of bson names, the same that keys() returns
+func setConfField(v reflect.Value, key string, val interface{}) {
+	path := strings.SplitN(key, ".", 2)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.TrimSpace(strings.Split(t.Field(i).Tag.Get("bson"), ",")[0])
+		if name != path[0] {
+			continue
+		}
+
+		f := v.Field(i)
+		if f.Kind() == reflect.Ptr {
+			if f.IsNil() {
+				f.Set(reflect.New(f.Type().Elem()))
+			}
+			f = f.Elem()
+		}
+		switch f.Kind() {
+		case reflect.Struct:
+			if len(path) == 2 {
+				setConfField(f, path[1], val)
+			}
+		case reflect.String:
+			f.SetString(val.(string))
+		case reflect.Int, reflect.Int64:
+			f.SetInt(val.(int64))
+		case reflect.Float32, reflect.Float64:
+			f.SetFloat(val.(float64))
+		case reflect.Bool:
+			f.SetBool(val.(bool))
+		}
+		return
+	}
+}
+
 func (p *PBM) SetConfigByte(buf []byte) error {
 	var cfg Config
 	err := yaml.UnmarshalStrict(buf, &cfg)
@@ -199,11 +240,29 @@ func (p *PBM) SetConfig(cfg Config) error {
 		if err != nil {
 			return errors.Wrap(err, "check config")
 		}
+	case StorageAzure:
+		err := cfg.Storage.Azure.Cast()
+		if err != nil {
+			return errors.Wrap(err, "check config")
+		}
 	}
 
 	if c := string(cfg.PITR.Compression); c != "" && !isValidCompressionType(c) {
 		return errors.Errorf("unsupported compression type: %q", c)
 	}
+	if c := string(cfg.Backup.Compression); c != "" && !isValidCompressionType(c) {
+		return errors.Errorf("unsupported compression type: %q", c)
+	}
+	if cfg.Backup.CompressionLevel != nil {
+		c := cfg.Backup.Compression
+		if c == "" {
+			c = CompressionTypeS2
+		}
+		err := CheckCompressionLevel(c, *cfg.Backup.CompressionLevel)
+		if err != nil {
+			return errors.Wrap(err, "backup.compressionLevel")
+		}
+	}
 
 	ct, err := p.ClusterTime()
 	if err != nil {
@@ -231,7 +290,7 @@ func (p *PBM) SetConfigVar(key, val string) error {
 	}
 
 	// just check if config was set
-	_, err := p.GetConfig()
+	cfg, err := p.GetConfig()
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return errors.New("config is not set")
@@ -258,7 +317,7 @@ func (p *PBM) SetConfigVar(key, val string) error {
 	switch key {
 	case "pitr.enabled":
 		return errors.Wrap(p.confSetPITR(key, v.(bool)), "write to db")
-	case "pitr.compression":
+	case "pitr.compression", "backup.compression":
 		if c := v.(string); c != "" && !isValidCompressionType(c) {
 			return errors.Errorf("unsupported compression type: %q", c)
 		}
@@ -266,10 +325,23 @@ func (p *PBM) SetConfigVar(key, val string) error {
 		if v.(string) == "" {
 			return errors.New("storage.filesystem.path can't be empty")
 		}
+		if !filepath.IsAbs(v.(string)) {
+			return errors.Errorf("storage.filesystem.path should be absolute, got %q", v.(string))
+		}
 	case "storage.s3.debugLogLevels":
 		s3.SDKLogLevel(v.(string), os.Stderr)
 	}
 
+	// s3 options depend on each other, so check them altogether
+	// the same way SetConfig does
+	if strings.HasPrefix(key, "storage.s3.") {
+		setConfField(reflect.ValueOf(&cfg).Elem(), key, v)
+		err = cfg.Storage.S3.Cast()
+		if err != nil {
+			return errors.Wrap(err, "cast storage")
+		}
+	}
+
 	_, err = p.Conn.Database(DB).Collection(ConfigCollection).UpdateOne(
 		p.ctx,
 		bson.D{},
@@ -0,0 +1,35 @@
+package pbm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetConfField(t *testing.T) {
+	var c Config
+	v := reflect.ValueOf(&c).Elem()
+
+	setConfField(v, "storage.s3.storageClass", "GLACIER")
+	setConfField(v, "storage.s3.serverSideEncryption.kmsKeyID", "key")
+	setConfField(v, "storage.s3.credentials.access-key-id", "id")
+	setConfField(v, "storage.s3.uploadPartSize", int64(42))
+	setConfField(v, "storage.s3.insecureSkipTLSVerify", true)
+	setConfField(v, "storage.s3.unknown", "x")
+
+	s3c := c.Storage.S3
+	if s3c.StorageClass != "GLACIER" {
+		t.Errorf("storageClass: got %q", s3c.StorageClass)
+	}
+	if s3c.ServerSideEncryption == nil || s3c.ServerSideEncryption.KmsKeyID != "key" {
+		t.Errorf("serverSideEncryption: got %+v", s3c.ServerSideEncryption)
+	}
+	if s3c.Credentials.AccessKeyID != "id" {
+		t.Errorf("credentials.access-key-id: got %q", s3c.Credentials.AccessKeyID)
+	}
+	if s3c.UploadPartSize != 42 {
+		t.Errorf("uploadPartSize: got %d", s3c.UploadPartSize)
+	}
+	if !s3c.InsecureSkipTLSVerify {
+		t.Error("insecureSkipTLSVerify: got false")
+	}
+}
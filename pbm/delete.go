@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/percona/percona-backup-mongodb/pbm/log"
 	"github.com/percona/percona-backup-mongodb/pbm/storage"
@@ -142,9 +143,34 @@ func (p *PBM) DeleteOlderThan(t time.Time, l *log.Event) error {
 		return errors.Wrap(err, "get storage")
 	}
 
+	bcps, err := p.BackupsToDelete(t, l)
+	if err != nil {
+		return err
+	}
+
+	for i := range bcps {
+		m := &bcps[i]
+		err = p.DeleteBackupFiles(m, stg)
+		if err != nil {
+			return errors.Wrap(err, "delete backup files from storage")
+		}
+
+		_, err = p.Conn.Database(DB).Collection(BcpCollection).DeleteOne(p.ctx, bson.M{"name": m.Name})
+		if err != nil {
+			return errors.Wrap(err, "delete backup meta from db")
+		}
+	}
+
+	return nil
+}
+
+// BackupsToDelete returns backups older than given Time that DeleteOlderThan
+// deletes. Backups which can't be deleted (e.g. a base for the PITR timeline)
+// are skipped and the reason is logged if l isn't nil.
+func (p *PBM) BackupsToDelete(t time.Time, l *log.Event) ([]BackupMeta, error) {
 	tlns, err := p.PITRTimelines()
 	if err != nil {
-		return errors.Wrap(err, "get PITR chunks")
+		return nil, errors.Wrap(err, "get PITR chunks")
 	}
 
 	cur, err := p.Conn.Database(DB).Collection(BcpCollection).Find(
@@ -152,40 +178,32 @@ func (p *PBM) DeleteOlderThan(t time.Time, l *log.Event) error {
 		bson.M{
 			"start_ts": bson.M{"$lt": t.Unix()},
 		},
+		options.Find().SetSort(bson.D{{"start_ts", -1}}),
 	)
 	if err != nil {
-		return errors.Wrap(err, "get backups list")
+		return nil, errors.Wrap(err, "get backups list")
 	}
 	defer cur.Close(p.ctx)
+
+	var bcps []BackupMeta
 	for cur.Next(p.ctx) {
-		m := new(BackupMeta)
-		err := cur.Decode(m)
+		m := BackupMeta{}
+		err := cur.Decode(&m)
 		if err != nil {
-			return errors.Wrap(err, "decode backup meta")
+			return nil, errors.Wrap(err, "decode backup meta")
 		}
 
-		err = p.probeDelete(m, tlns)
+		err = p.probeDelete(&m, tlns)
 		if err != nil {
-			l.Info("deleting %s: %v", m.Name, err)
+			if l != nil {
+				l.Info("deleting %s: %v", m.Name, err)
+			}
 			continue
 		}
-
-		err = p.DeleteBackupFiles(m, stg)
-		if err != nil {
-			return errors.Wrap(err, "delete backup files from storage")
-		}
-
-		_, err = p.Conn.Database(DB).Collection(BcpCollection).DeleteOne(p.ctx, bson.M{"name": m.Name})
-		if err != nil {
-			return errors.Wrap(err, "delete backup meta from db")
-		}
-	}
-
-	if cur.Err() != nil {
-		return errors.Wrap(cur.Err(), "cursor")
+		bcps = append(bcps, m)
 	}
 
-	return nil
+	return bcps, errors.Wrap(cur.Err(), "cursor")
 }
 
 // DeletePITR deletes backups which older than given `until` Time. It will round `until` down
@@ -277,6 +277,142 @@ func (e Entries) String() (s string) {
 }
 
 func Get(cn *mongo.Collection, r *LogRequest, limit int64, exactSeverity bool) (*Entries, error) {
+	cur, err := cn.Find(
+		context.TODO(),
+		buildFilter(r, exactSeverity),
+		options.Find().SetLimit(limit).SetSort(bson.D{{"ts", -1}, {"ns", -1}}),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "get list from mongo")
+	}
+	defer cur.Close(context.TODO())
+
+	e := new(Entries)
+	for cur.Next(context.TODO()) {
+		l := Entry{}
+		err := cur.Decode(&l)
+		if err != nil {
+			return nil, errors.Wrap(err, "message decode")
+		}
+		if id, ok := cur.Current.Lookup("_id").ObjectIDOK(); ok {
+			l.ObjID = id
+		}
+		e.Data = append(e.Data, l)
+	}
+
+	return e, nil
+}
+
+// Follow calls f for each new log entry written after the entry with
+// the `after` id (or after the last one if `after` is nil) until ctx is done.
+// It relies on the log collection being capped and reads it in the natural
+// (insertion) order with a single tailable cursor. ObjectIDs are made by agents
+// with their own clocks, so they can't be compared to find new entries.
+func Follow(ctx context.Context, cn *mongo.Collection, r *LogRequest, after primitive.ObjectID, exactSeverity bool, f func(*Entry) error) error {
+	if after.IsZero() {
+		res := cn.FindOne(ctx, bson.D{}, options.FindOne().SetSort(bson.D{{"$natural", -1}}))
+		raw, err := res.DecodeBytes()
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return errors.Wrap(err, "get last entry")
+		}
+		if err == nil {
+			after, _ = raw.Lookup("_id").ObjectIDOK()
+		}
+	}
+	// skip entries up to and including `after`
+	skip := !after.IsZero()
+
+	for {
+		cur, err := cn.Find(ctx, bson.D{}, options.Find().SetCursorType(options.TailableAwait))
+		if err != nil {
+			return errors.Wrap(err, "open tailable cursor")
+		}
+
+		for {
+			var ok bool
+			if skip {
+				ok = cur.TryNext(ctx)
+				if !ok && cur.Err() == nil && cur.ID() != 0 {
+					// read everything but haven't met `after`, so it was
+					// overwritten in the capped collection and all seen entries are old
+					skip = false
+					continue
+				}
+			} else {
+				ok = cur.Next(ctx)
+			}
+			if !ok {
+				break
+			}
+
+			id, _ := cur.Current.Lookup("_id").ObjectIDOK()
+			if skip {
+				if id == after {
+					skip = false
+				}
+				continue
+			}
+			after = id
+
+			l := Entry{}
+			err = cur.Decode(&l)
+			if err != nil {
+				err = errors.Wrap(err, "message decode")
+				break
+			}
+			l.ObjID = id
+			if !r.match(&l, exactSeverity) {
+				continue
+			}
+			err = f(&l)
+			if err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = cur.Err()
+		}
+		cur.Close(context.Background())
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return err
+		}
+
+		// the cursor is dead (e.g. the collection was empty),
+		// reopen it and skip what has been read already
+		skip = !after.IsZero()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// match reports whether the entry satisfies the request.
+// It mirrors buildFilter for entries filtered on the client side.
+func (r *LogRequest) match(e *Entry, exactSeverity bool) bool {
+	if exactSeverity && e.Severity != r.Severity || !exactSeverity && e.Severity > r.Severity {
+		return false
+	}
+	switch {
+	case r.RS != "" && e.RS != r.RS,
+		r.Node != "" && e.Node != r.Node,
+		r.Event != "" && e.Event != r.Event,
+		r.ObjName != "" && e.ObjName != r.ObjName,
+		r.Epoch.T > 0 && !r.Epoch.Equal(e.Epoch),
+		r.OPID != "" && e.OPID != r.OPID,
+		!r.TimeMin.IsZero() && e.TS < r.TimeMin.Unix(),
+		!r.TimeMax.IsZero() && e.TS > r.TimeMax.Unix():
+		return false
+	}
+
+	return true
+}
+
+func buildFilter(r *LogRequest, exactSeverity bool) bson.D {
 	filter := bson.D{bson.E{"s", bson.M{"$lte": r.Severity}}}
 	if exactSeverity {
 		filter = bson.D{bson.E{"s", r.Severity}}
@@ -307,28 +443,5 @@ func Get(cn *mongo.Collection, r *LogRequest, limit int64, exactSeverity bool) (
 		filter = append(filter, bson.E{"ts", bson.M{"$lte": r.TimeMax.Unix()}})
 	}
 
-	cur, err := cn.Find(
-		context.TODO(),
-		filter,
-		options.Find().SetLimit(limit).SetSort(bson.D{{"ts", -1}, {"ns", -1}}),
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "get list from mongo")
-	}
-	defer cur.Close(context.TODO())
-
-	e := new(Entries)
-	for cur.Next(context.TODO()) {
-		l := Entry{}
-		err := cur.Decode(&l)
-		if err != nil {
-			return nil, errors.Wrap(err, "message decode")
-		}
-		if id, ok := cur.Current.Lookup("_id").ObjectIDOK(); ok {
-			l.ObjID = id
-		}
-		e.Data = append(e.Data, l)
-	}
-
-	return e, nil
+	return filter
 }
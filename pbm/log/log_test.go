@@ -0,0 +1,44 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRequestMatch(t *testing.T) {
+	e := &Entry{
+		TS: 1000,
+		LogKeys: LogKeys{
+			Severity: Warning,
+			RS:       "rs1",
+			Node:     "rs1:27017",
+			Event:    "backup",
+			ObjName:  "2022-01-01T00:00:00Z",
+			OPID:     "opid1",
+		},
+	}
+	cases := []struct {
+		name  string
+		r     LogRequest
+		exact bool
+		want  bool
+	}{
+		{"severity below", LogRequest{LogKeys: LogKeys{Severity: Info}}, false, true},
+		{"severity above", LogRequest{LogKeys: LogKeys{Severity: Error}}, false, false},
+		{"exact severity", LogRequest{LogKeys: LogKeys{Severity: Warning}}, true, true},
+		{"exact severity mismatch", LogRequest{LogKeys: LogKeys{Severity: Info}}, true, false},
+		{"rs", LogRequest{LogKeys: LogKeys{Severity: Debug, RS: "rs1"}}, false, true},
+		{"other rs", LogRequest{LogKeys: LogKeys{Severity: Debug, RS: "rs2"}}, false, false},
+		{"node", LogRequest{LogKeys: LogKeys{Severity: Debug, Node: "rs2:27017"}}, false, false},
+		{"event", LogRequest{LogKeys: LogKeys{Severity: Debug, Event: "restore"}}, false, false},
+		{"opid", LogRequest{LogKeys: LogKeys{Severity: Debug, OPID: "opid1"}}, false, true},
+		{"time in", LogRequest{LogKeys: LogKeys{Severity: Debug}, TimeMin: time.Unix(900, 0), TimeMax: time.Unix(1000, 0)}, false, true},
+		{"time before", LogRequest{LogKeys: LogKeys{Severity: Debug}, TimeMin: time.Unix(1001, 0)}, false, false},
+		{"time after", LogRequest{LogKeys: LogKeys{Severity: Debug}, TimeMax: time.Unix(999, 0)}, false, false},
+	}
+	for _, c := range cases {
+		if got := c.r.match(e, c.exact); got != c.want {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+}
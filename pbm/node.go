@@ -178,19 +178,66 @@ func (n *Node) ReplicationLag() (int, error) {
 		return -1, errors.Wrap(err, "get replset status")
 	}
 
-	name := n.Name()
+	primaryOptime, _ := s.primaryOptime()
+	for _, m := range s.Members {
+		if m.Name == n.Name() {
+			return primaryOptime - m.optime(), nil
+		}
+	}
+
+	return primaryOptime, nil
+}
+
+// SecondariesLag returns replication lag in seconds of each secondary
+// member of the node's replicaset. Members that are still catching up
+// (e.g. in initial sync or recovering) are included as well.
+func (n *Node) SecondariesLag() (map[string]int, error) {
+	s, err := n.GetReplsetStatus()
+	if err != nil {
+		return nil, errors.Wrap(err, "get replset status")
+	}
+
+	return s.secondariesLag()
+}
 
-	var primaryOptime, nodeOptime int
+func (s *ReplsetStatus) secondariesLag() (map[string]int, error) {
+	primaryOptime, ok := s.primaryOptime()
+	if !ok {
+		return nil, errors.New("no primary in the replset")
+	}
+
+	lag := make(map[string]int)
 	for _, m := range s.Members {
-		if m.Name == name {
-			nodeOptime = int(m.Optime.TS.T)
+		switch m.State {
+		case NodeStateSecondary, NodeStateStartup, NodeStateStartup2,
+			NodeStateRecovering, NodeStateRollback:
+			lag[m.Name] = primaryOptime - m.optime()
 		}
-		if m.StateStr == "PRIMARY" {
-			primaryOptime = int(m.Optime.TS.T)
+	}
+
+	return lag, nil
+}
+
+// primaryOptime returns the primary's last optime in seconds
+// or false if there is no primary
+func (s *ReplsetStatus) primaryOptime() (int, bool) {
+	for _, m := range s.Members {
+		if m.State == NodeStatePrimary {
+			return m.optime(), true
 		}
 	}
 
-	return primaryOptime - nodeOptime, nil
+	return 0, false
+}
+
+// optime returns the member's last optime in seconds. It's zero
+// if the member has not applied anything yet (e.g. in initial sync).
+func (m *NodeStatus) optime() int {
+	if m.Optime == nil {
+		return 0
+	}
+
+	return int(m.Optime.TS.T)
 }
 
 func (n *Node) ConnURI() string {
@@ -0,0 +1,37 @@
+package pbm
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSecondariesLag(t *testing.T) {
+	ot := func(sec uint32) *OpTime {
+		return &OpTime{TS: primitive.Timestamp{T: sec}}
+	}
+
+	s := ReplsetStatus{Members: []NodeStatus{
+		{Name: "rs0:27017", State: NodeStatePrimary, Optime: ot(100)},
+		{Name: "rs1:27017", State: NodeStateSecondary, Optime: ot(95)},
+		{Name: "rs2:27017", State: NodeStateStartup2},
+		{Name: "rs3:27017", State: NodeStateRecovering, Optime: ot(40)},
+		{Name: "rs4:27017", State: NodeStateArbiter},
+		{Name: "rs5:27017", State: NodeStateDown, Optime: ot(10)},
+	}}
+	lag, err := s.secondariesLag()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"rs1:27017": 5, "rs2:27017": 100, "rs3:27017": 60}
+	if !reflect.DeepEqual(lag, want) {
+		t.Errorf("expected %v, got %v", want, lag)
+	}
+
+	s.Members[0].State = NodeStateSecondary
+	_, err = s.secondariesLag()
+	if err == nil {
+		t.Error("expected error with no primary")
+	}
+}
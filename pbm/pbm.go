@@ -74,6 +74,7 @@ const (
 	CmdPITRestore   Command = "pitrestore"
 	CmdDeleteBackup Command = "delete"
 	CmdDeletePITR   Command = "deletePitr"
+	CmdCheckStorage Command = "checkStorage"
 )
 
 func (c Command) String() string {
@@ -96,6 +97,8 @@ func (c Command) String() string {
 		return "Delete"
 	case CmdDeletePITR:
 		return "Delete PITR chunks"
+	case CmdCheckStorage:
+		return "Check storage"
 	default:
 		return "Undefined"
 	}
@@ -162,6 +165,20 @@ type BackupCmd struct {
 	Name             string          `bson:"name"`
 	Compression      CompressionType `bson:"compression"`
 	CompressionLevel *int            `bson:"level,omitempty"`
+	// NumParallelCollections overrides the agent's number of collections
+	// to dump in parallel. Applies to logical backups only.
+	NumParallelCollections int `bson:"numParallelColls,omitempty"`
+	// CompressionThreads is the number of goroutines used by compressors
+	// that support concurrency (pgzip, s2, zstd). Zero means the default.
+	CompressionThreads int `bson:"compressionThreads,omitempty"`
+	// Labels are arbitrary key-value tags stored in the backup metadata
+	Labels map[string]string `bson:"labels,omitempty"`
+	// MaxUploadMbps limits the upload rate to the storage, in megabits
+	// per second. Zero means no limit.
+	MaxUploadMbps int `bson:"maxUploadMbps,omitempty"`
+	// UploadRetries is the number of times a failed file upload is retried
+	// before the backup fails. Applies to physical backups only.
+	UploadRetries int `bson:"uploadRetries,omitempty"`
 }
 
 func (b BackupCmd) String() string {
@@ -178,6 +195,14 @@ type RestoreCmd struct {
 	Name       string            `bson:"name"`
 	BackupName string            `bson:"backupName"`
 	RSMap      map[string]string `bson:"rsMap,omitempty"`
+	// MaxReplLag if set makes the restore wait until the replication lag
+	// of all secondaries is within MaxReplLag seconds before finishing
+	MaxReplLag *int `bson:"maxReplLag,omitempty"`
+	// ReplWaitTimeout is how long in seconds to wait for the replication,
+	// the default one is used if not set
+	ReplWaitTimeout int `bson:"replWaitTimeout,omitempty"`
+	// Namespaces to restore (e.g. "db.*", "db.coll"). Empty means all.
+	Namespaces []string `bson:"nss,omitempty"`
 }
 
 func (r RestoreCmd) String() string {
@@ -201,6 +226,9 @@ type PITRestoreCmd struct {
 	I     int64             `bson:"i"`
 	Bcp   string            `bson:"bcp"`
 	RSMap map[string]string `bson:"rsMap,omitempty"`
+	// MaxReplLag and ReplWaitTimeout are the same as in RestoreCmd
+	MaxReplLag      *int `bson:"maxReplLag,omitempty"`
+	ReplWaitTimeout int  `bson:"replWaitTimeout,omitempty"`
 }
 
 func (p PITRestoreCmd) String() string {
@@ -235,6 +263,32 @@ const (
 	CompressionTypeZstandard CompressionType = "zstd"
 )
 
+// CheckCompressionLevel checks if the level is valid for the given compression type
+func CheckCompressionLevel(c CompressionType, level int) error {
+	var min, max int
+	switch c {
+	case CompressionTypeGZIP, CompressionTypePGZIP:
+		min, max = 1, 9
+	case CompressionTypeS2:
+		min, max = 1, 4
+	case CompressionTypeZstandard:
+		min, max = 1, 22
+	case CompressionTypeLZ4:
+		if level < 0 {
+			return errors.Errorf("compression level for %s can't be negative, got %d", c, level)
+		}
+		return nil
+	default:
+		return errors.Errorf("compression level isn't supported for %s compression", c)
+	}
+
+	if level < min || level > max {
+		return errors.Errorf("compression level for %s should be in range [%d, %d], got %d", c, min, max, level)
+	}
+
+	return nil
+}
+
 func isValidCompressionType(s string) bool {
 	switch CompressionType(s) {
 	case
@@ -545,6 +599,7 @@ type BackupMeta struct {
 	Error            string               `bson:"error,omitempty" json:"error,omitempty"`
 	PBMVersion       string               `bson:"pbm_version,omitempty" json:"pbm_version,omitempty"`
 	BalancerStatus   BalancerMode         `bson:"balancer" json:"balancer"`
+	Labels           map[string]string    `bson:"labels,omitempty" json:"labels,omitempty"`
 }
 
 // BackupRsNomination is used to choose (nominate and elect) nodes for the backup
@@ -609,6 +664,19 @@ func (p *PBM) SetBackupMeta(m *BackupMeta) error {
 	return err
 }
 
+// ReplaceBackupMeta stores the backup metadata as is, replacing the existing
+// backup with the same name if any.
+func (p *PBM) ReplaceBackupMeta(m *BackupMeta) error {
+	_, err := p.Conn.Database(DB).Collection(BcpCollection).ReplaceOne(
+		p.ctx,
+		bson.D{{"name", m.Name}},
+		m,
+		options.Replace().SetUpsert(true),
+	)
+
+	return err
+}
+
 // RS returns the metada of the replset with given name.
 // It returns nil if no replsent found.
 func (b *BackupMeta) RS(name string) *BackupReplset {
@@ -975,6 +1043,11 @@ func (p *PBM) LogGet(r *log.LogRequest, limit int64) (*log.Entries, error) {
 	return log.Get(p.Conn.Database(DB).Collection(LogCollection), r, limit, false)
 }
 
+// LogFollow calls f for each new log entry until the PBM context is done
+func (p *PBM) LogFollow(r *log.LogRequest, after primitive.ObjectID, f func(*log.Entry) error) error {
+	return log.Follow(p.ctx, p.Conn.Database(DB).Collection(LogCollection), r, after, false, f)
+}
+
 func (p *PBM) LogGetExactSeverity(r *log.LogRequest, limit int64) (*log.Entries, error) {
 	return log.Get(p.Conn.Database(DB).Collection(LogCollection), r, limit, true)
 }
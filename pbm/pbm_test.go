@@ -0,0 +1,44 @@
+package pbm
+
+import "testing"
+
+func TestCheckCompressionLevel(t *testing.T) {
+	cases := []struct {
+		c     CompressionType
+		level int
+		err   bool
+	}{
+		{CompressionTypeGZIP, 1, false},
+		{CompressionTypeGZIP, 9, false},
+		{CompressionTypeGZIP, 0, true},
+		{CompressionTypeGZIP, -1, true},
+		{CompressionTypeGZIP, -2, true},
+		{CompressionTypeGZIP, -3, true},
+		{CompressionTypeGZIP, 10, true},
+		{CompressionTypePGZIP, 1, false},
+		{CompressionTypePGZIP, 9, false},
+		{CompressionTypePGZIP, 0, true},
+		{CompressionTypePGZIP, -1, true},
+		{CompressionTypePGZIP, -3, true},
+		{CompressionTypePGZIP, 10, true},
+		{CompressionTypeS2, 1, false},
+		{CompressionTypeS2, 4, false},
+		{CompressionTypeS2, 0, true},
+		{CompressionTypeS2, 5, true},
+		{CompressionTypeZstandard, 1, false},
+		{CompressionTypeZstandard, 22, false},
+		{CompressionTypeZstandard, 0, true},
+		{CompressionTypeZstandard, 23, true},
+		{CompressionTypeLZ4, 0, false},
+		{CompressionTypeLZ4, 16, false},
+		{CompressionTypeLZ4, -1, true},
+		{CompressionTypeSNAPPY, 1, true},
+		{CompressionTypeNone, 0, true},
+	}
+	for _, c := range cases {
+		err := CheckCompressionLevel(c.c, c.level)
+		if (err != nil) != c.err {
+			t.Errorf("%s level %d: expected error %v, got %v", c.c, c.level, c.err, err)
+		}
+	}
+}
@@ -40,6 +40,9 @@ type RestoreReplset struct {
 	Error            string              `bson:"error,omitempty" json:"error,omitempty"`
 	Conditions       []Condition         `bson:"conditions" json:"conditions"`
 	Hb               primitive.Timestamp `bson:"hb" json:"hb"`
+	// ReplLag is the replication lag in seconds of secondaries
+	// by the end of the wait for replication
+	ReplLag map[string]int `bson:"repl_lag,omitempty" json:"repl_lag,omitempty"`
 }
 
 type RestoreNode struct {
@@ -89,6 +92,16 @@ func (p *PBM) SetCurrentOp(name string, rsName string, ts primitive.Timestamp) e
 	return err
 }
 
+func (p *PBM) SetRestoreReplLag(name string, rsName string, lag map[string]int) error {
+	_, err := p.Conn.Database(DB).Collection(RestoresCollection).UpdateOne(
+		p.ctx,
+		bson.D{{"name", name}, {"replsets.name", rsName}},
+		bson.D{{"$set", bson.M{"replsets.$.repl_lag": lag}}},
+	)
+
+	return err
+}
+
 func (p *PBM) SetRestoreMeta(m *RestoreMeta) error {
 	m.LastTransitionTS = m.StartTS
 	m.Conditions = append(m.Conditions, Condition{
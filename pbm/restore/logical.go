@@ -47,6 +47,10 @@ var excludeFromRestore = []string{
 	"config.system.indexBuilds",
 }
 
+// defaultReplWaitTimeout is how long the restore waits for secondaries
+// to catch up if the timeout isn't set in the command
+const defaultReplWaitTimeout = time.Minute * 10
+
 var excludeFromOplog = []string{
 	"config.rangeDeletions",
 	pbm.DB + "." + pbm.TmpUsersCollection,
@@ -68,6 +72,8 @@ type Restore struct {
 	// Only the restore leader would have this info.
 	shards []pbm.Shard
 	rsMap  map[string]string
+	// Namespaces to restore. Empty means a full restore.
+	nss []string
 
 	oplog *Oplog
 	log   *log.Event
@@ -120,6 +126,8 @@ func (r *Restore) Snapshot(cmd pbm.RestoreCmd, opid pbm.OPID, l *log.Event) (err
 		return errors.Wrap(err, "set backup name")
 	}
 
+	r.nss = cmd.Namespaces
+
 	bcp, err := r.SnapshotMeta(cmd.BackupName)
 	if err != nil {
 		return err
@@ -156,6 +164,13 @@ func (r *Restore) Snapshot(cmd pbm.RestoreCmd, opid pbm.OPID, l *log.Event) (err
 		return err
 	}
 
+	if cmd.MaxReplLag != nil {
+		err = r.waitForReplication(*cmd.MaxReplLag, cmd.ReplWaitTimeout)
+		if err != nil {
+			return errors.Wrap(err, "wait for replication")
+		}
+	}
+
 	return r.Done()
 }
 
@@ -238,6 +253,13 @@ func (r *Restore) PITR(cmd pbm.PITRestoreCmd, opid pbm.OPID, l *log.Event) (err
 		return err
 	}
 
+	if cmd.MaxReplLag != nil {
+		err = r.waitForReplication(*cmd.MaxReplLag, cmd.ReplWaitTimeout)
+		if err != nil {
+			return errors.Wrap(err, "wait for replication")
+		}
+	}
+
 	return r.Done()
 }
 
@@ -531,6 +553,11 @@ func (r *Restore) RunSnapshot(dump string, bcp *pbm.BackupMeta) (err error) {
 		return errors.Wrap(err, "mongorestore")
 	}
 
+	if len(r.nss) > 0 {
+		r.log.Info("selective restore of %v, users and roles are not restored", r.nss)
+		return nil
+	}
+
 	r.log.Info("restoring users and roles")
 	cusr, err := r.node.CurrentUser()
 	if err != nil {
@@ -638,6 +665,12 @@ func (r *Restore) applyOplog(chunks []pbm.OplogChunk, start, end *primitive.Time
 	if err != nil {
 		return errors.Wrap(err, "create oplog")
 	}
+	if len(r.nss) > 0 {
+		err = r.oplog.SetIncludeNS(r.nss)
+		if err != nil {
+			return errors.Wrap(err, "set namespaces to restore")
+		}
+	}
 
 	var startTS, endTS primitive.Timestamp
 	if start != nil {
@@ -856,6 +889,7 @@ func (r *Restore) snapshot(input io.Reader) (err error) {
 		WriteConcern:             "majority",
 	}
 	mopts.NSOptions = &mongorestore.NSOptions{
+		NSInclude: r.nss,
 		NSExclude: excludeFromRestore,
 	}
 
@@ -893,6 +927,58 @@ func (r *Restore) replyChunk(file string, c pbm.CompressionType) (lts primitive.
 	return lts, errors.Wrap(err, "apply oplog for chunk")
 }
 
+// waitForReplication waits until the replication lag of all secondaries
+// of the replset is within maxLag seconds. The last seen lag is saved
+// to the restore metadata.
+func (r *Restore) waitForReplication(maxLag, timeoutSec int) error {
+	timeout := defaultReplWaitTimeout
+	if timeoutSec > 0 {
+		timeout = time.Duration(timeoutSec) * time.Second
+	}
+	r.log.Info("waiting for secondaries to catch up, max lag %ds, timeout %v", maxLag, timeout)
+
+	tk := time.NewTicker(time.Second * 1)
+	defer tk.Stop()
+	tout := time.NewTimer(timeout)
+	defer tout.Stop()
+
+	var lag map[string]int
+	defer func() {
+		if lag == nil {
+			return
+		}
+		err := r.cn.SetRestoreReplLag(r.name, r.nodeInfo.SetName, lag)
+		if err != nil {
+			r.log.Warning("save replication lag: %v", err)
+		}
+	}()
+
+	for {
+		select {
+		case <-tk.C:
+			l, err := r.node.SecondariesLag()
+			if err != nil {
+				return errors.Wrap(err, "get secondaries lag")
+			}
+			lag = l
+
+			caughtUp := true
+			for _, l := range lag {
+				if l > maxLag {
+					caughtUp = false
+					break
+				}
+			}
+			if caughtUp {
+				r.log.Info("replication lag: %v", lag)
+				return nil
+			}
+		case <-tout.C:
+			return errors.Errorf("secondaries didn't catch up in %v, replication lag: %v", timeout, lag)
+		}
+	}
+}
+
 // Done waits for the replicas to finish the job
 // and marks restore as done
 func (r *Restore) Done() error {
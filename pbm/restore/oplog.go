@@ -61,6 +61,9 @@ type Oplog struct {
 	endTS             primitive.Timestamp
 	indexCatalog      *idx.IndexCatalog
 	m                 *ns.Matcher
+	// incl defines namespaces to apply ops for.
+	// nil means all but excluded by `m`
+	incl *ns.Matcher
 
 	txn        chan pbm.RestoreTxn
 	txnSyncErr chan error
@@ -104,6 +107,49 @@ func NewOplog(dst *pbm.Node, sv *pbm.MongoVersion, unsafe, preserveUUID bool, ct
 	}, nil
 }
 
+// SetIncludeNS limits applied operations to the given namespaces
+// (e.g. "db.*", "db.coll")
+func (o *Oplog) SetIncludeNS(nss []string) error {
+	m, err := ns.NewMatcher(nss)
+	if err != nil {
+		return errors.Wrap(err, "create matcher for the collections include")
+	}
+	o.incl = m
+
+	return nil
+}
+
+// included returns true if the op is on included namespace. For commands it
+// checks the namespace of the collection the command is run against.
+func (o *Oplog) included(op db.Oplog) bool {
+	if o.incl == nil {
+		return true
+	}
+
+	nspace := op.Namespace
+	if op.Operation == "c" && len(op.Object) > 0 {
+		cmd := op.Object[0]
+		// ops nested in applyOps are checked on their own
+		if cmd.Key == "applyOps" {
+			return true
+		}
+
+		dbName, _ := util.SplitNamespace(op.Namespace)
+		switch c := cmd.Value.(type) {
+		case string:
+			nspace = dbName + "." + c
+			if cmd.Key == "renameCollection" {
+				nspace = c
+			}
+		default:
+			// database-wide command, e.g. dropDatabase
+			nspace = dbName + ".*"
+		}
+	}
+
+	return o.incl.Has(nspace)
+}
+
 // SetTimeframe sets boundaries for the replayed operations. All operations
 // that happened before `start` and after `end` are going to be discarded.
 // Zero `end` (primitive.Timestamp{T:0}) means all chunks will be replayed
@@ -333,7 +379,7 @@ Loop:
 func (o *Oplog) handleNonTxnOp(op db.Oplog) error {
 	// have to handle it here one more time because before the op gets thru
 	// txnBuffer its namespace is `collection.$cmd` instead of the real one
-	if o.m.Has(op.Namespace) {
+	if o.m.Has(op.Namespace) || !o.included(op) {
 		return nil
 	}
 
@@ -0,0 +1,48 @@
+package restore
+
+import (
+	"testing"
+
+	"github.com/mongodb/mongo-tools/common/db"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestOplogIncluded(t *testing.T) {
+	cases := []struct {
+		name string
+		op   db.Oplog
+		want bool
+	}{
+		{"insert into included db", db.Oplog{Operation: "i", Namespace: "db1.c"}, true},
+		{"insert into included coll", db.Oplog{Operation: "i", Namespace: "db2.coll"}, true},
+		{"insert into other coll", db.Oplog{Operation: "i", Namespace: "db2.other"}, false},
+		{"insert into other db", db.Oplog{Operation: "i", Namespace: "db3.coll"}, false},
+		{"insert into db with included prefix", db.Oplog{Operation: "i", Namespace: "db10.c"}, false},
+		{"create in included db", db.Oplog{Operation: "c", Namespace: "db1.$cmd", Object: bson.D{{"create", "c"}}}, true},
+		{"create other coll", db.Oplog{Operation: "c", Namespace: "db2.$cmd", Object: bson.D{{"create", "other"}}}, false},
+		{"drop included coll", db.Oplog{Operation: "c", Namespace: "db2.$cmd", Object: bson.D{{"drop", "coll"}}}, true},
+		{"drop included db", db.Oplog{Operation: "c", Namespace: "db1.$cmd", Object: bson.D{{"dropDatabase", 1}}}, true},
+		{"drop db of included coll", db.Oplog{Operation: "c", Namespace: "db2.$cmd", Object: bson.D{{"dropDatabase", 1}}}, false},
+		{"rename included coll", db.Oplog{Operation: "c", Namespace: "admin.$cmd", Object: bson.D{{"renameCollection", "db2.coll"}, {"to", "db3.coll"}}}, true},
+		{"rename other coll", db.Oplog{Operation: "c", Namespace: "admin.$cmd", Object: bson.D{{"renameCollection", "db3.coll"}, {"to", "db2.coll"}}}, false},
+		// nested ops go through the check on their own
+		{"applyOps", db.Oplog{Operation: "c", Namespace: "admin.$cmd", Object: bson.D{{"applyOps", bson.A{}}}}, true},
+	}
+
+	o := &Oplog{}
+	for _, c := range cases {
+		if !o.included(c.op) {
+			t.Errorf("%s: should be included with no namespaces set", c.name)
+		}
+	}
+
+	err := o.SetIncludeNS([]string{"db1.*", "db2.coll"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		if got := o.included(c.op); got != c.want {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+}
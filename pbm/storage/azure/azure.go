@@ -38,6 +38,25 @@ type Credentials struct {
 	Key string `bson:"key" json:"key,omitempty" yaml:"key,omitempty"`
 }
 
+// Cast checks that all required options are set
+func (c *Conf) Cast() error {
+	var missed []string
+	if c.Account == "" {
+		missed = append(missed, "account")
+	}
+	if c.Container == "" {
+		missed = append(missed, "container")
+	}
+	if c.Credentials.Key == "" {
+		missed = append(missed, "credentials.key")
+	}
+	if len(missed) > 0 {
+		return errors.Errorf("missing required options: %s", strings.Join(missed, ", "))
+	}
+
+	return nil
+}
+
 type Blob struct {
 	opts Conf
 	log  *log.Event
@@ -20,6 +20,9 @@ func (c *Conf) Cast() error {
 	if c.Path == "" {
 		return errors.New("path can't be empty")
 	}
+	if !filepath.IsAbs(c.Path) {
+		return errors.Errorf("path should be absolute, got %q", c.Path)
+	}
 
 	return nil
 }
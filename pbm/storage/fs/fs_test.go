@@ -0,0 +1,21 @@
+package fs
+
+import "testing"
+
+func TestCast(t *testing.T) {
+	cases := map[string]bool{
+		"":               true,
+		"backups":        true,
+		"./backups":      true,
+		"../backups":     true,
+		"/backups":       false,
+		"/mnt/pbm/data/": false,
+	}
+	for p, wantErr := range cases {
+		c := Conf{Path: p}
+		err := c.Cast()
+		if (err != nil) != wantErr {
+			t.Errorf("%q: expected error %v, got %v", p, wantErr, err)
+		}
+	}
+}
@@ -120,20 +120,43 @@ type AWSsse struct {
 }
 
 func (c *Conf) Cast() error {
+	// credentials may be omitted in favour of env vars or IAM role,
+	// but have to be complete if set
+	var missed []string
+	if c.Bucket == "" {
+		missed = append(missed, "bucket")
+	}
+	if c.Credentials.AccessKeyID == "" && c.Credentials.SecretAccessKey != "" {
+		missed = append(missed, "credentials.access-key-id")
+	}
+	if c.Credentials.AccessKeyID != "" && c.Credentials.SecretAccessKey == "" {
+		missed = append(missed, "credentials.secret-access-key")
+	}
+	if len(missed) > 0 {
+		return errors.Errorf("missing required options: %s", strings.Join(missed, ", "))
+	}
 	if c.Region == "" {
 		c.Region = defaultS3Region
 	}
+	if c.EndpointURL != "" {
+		// the SDK defaults to https if the scheme is omitted (e.g. `minio:9000`)
+		eurl := c.EndpointURL
+		if !strings.Contains(eurl, "://") {
+			eurl = "https://" + eurl
+		}
+		eu, err := url.Parse(eurl)
+		if err != nil {
+			return errors.Wrap(err, "parse EndpointURL")
+		}
+		if eu.Host == "" {
+			return errors.Errorf("invalid EndpointURL %q: should be in form [<scheme>://]<host>[:<port>]", c.EndpointURL)
+		}
+		if c.Provider == S3ProviderUndef && eu.Host == GCSEndpointURL {
+			c.Provider = S3ProviderGCS
+		}
+	}
 	if c.Provider == S3ProviderUndef {
 		c.Provider = S3ProviderAWS
-		if c.EndpointURL != "" {
-			eu, err := url.Parse(c.EndpointURL)
-			if err != nil {
-				return errors.Wrap(err, "parse EndpointURL")
-			}
-			if eu.Host == GCSEndpointURL {
-				c.Provider = S3ProviderGCS
-			}
-		}
 	}
 	if c.MaxUploadParts <= 0 {
 		c.MaxUploadParts = s3manager.MaxUploadParts
@@ -141,6 +164,13 @@ func (c *Conf) Cast() error {
 	if c.StorageClass == "" {
 		c.StorageClass = s3.StorageClassStandard
 	}
+	// other providers and S3-compatible storages may have their own classes
+	// (e.g. NEARLINE for GCS), so only AWS S3 ones are checked
+	isAWS := c.EndpointURL == "" || strings.Contains(c.EndpointURL, "amazonaws.com")
+	if c.Provider == S3ProviderAWS && isAWS && !isAWSStorageClass(c.StorageClass) {
+		return errors.Errorf("unknown storageClass %q, valid values: %s",
+			c.StorageClass, strings.Join(append(s3.StorageClass_Values(), storageClassGlacierIR), ", "))
+	}
 
 	if c.Retryer != nil {
 		if c.Retryer.MinRetryDelay == 0 {
@@ -189,6 +219,23 @@ func SDKLogLevel(levels string, out io.Writer) aws.LogLevelType {
 	return logLevel
 }
 
+// storageClassGlacierIR isn't known to the vendored SDK version
+// but is passed through to S3 as is
+const storageClassGlacierIR = "GLACIER_IR"
+
+func isAWSStorageClass(c string) bool {
+	if c == storageClassGlacierIR {
+		return true
+	}
+	for _, v := range s3.StorageClass_Values() {
+		if c == v {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Credentials struct {
 	AccessKeyID     string `bson:"access-key-id" json:"access-key-id,omitempty" yaml:"access-key-id,omitempty"`
 	SecretAccessKey string `bson:"secret-access-key" json:"secret-access-key,omitempty" yaml:"secret-access-key,omitempty"`
@@ -616,8 +663,10 @@ func (s *S3) session() (*session.Session, error) {
 		}})
 	}
 
-	// allow fetching credentials from env variables and ec2 metadata endpoint
+	// allow fetching credentials from env variables, the shared credentials
+	// file (~/.aws/credentials) and ec2 metadata endpoint
 	providers = append(providers, &credentials.EnvProvider{})
+	providers = append(providers, &credentials.SharedCredentialsProvider{})
 	providers = append(providers, &ec2rolecreds.EC2RoleProvider{
 		Client: ec2metadata.New(session.New()),
 	})
@@ -0,0 +1,67 @@
+package s3
+
+import "testing"
+
+func TestCastEndpointURL(t *testing.T) {
+	cases := []struct {
+		url      string
+		err      bool
+		provider S3Provider
+	}{
+		{"", false, S3ProviderAWS},
+		{"https://s3.us-east-1.amazonaws.com", false, S3ProviderAWS},
+		{"http://minio:9000", false, S3ProviderAWS},
+		{"minio:9000", false, S3ProviderAWS},
+		{"minio", false, S3ProviderAWS},
+		{"https://" + GCSEndpointURL, false, S3ProviderGCS},
+		{GCSEndpointURL, false, S3ProviderGCS},
+		{"https://", true, S3ProviderUndef},
+		{"http://[::1", true, S3ProviderUndef},
+	}
+	for _, c := range cases {
+		conf := Conf{Bucket: "b", EndpointURL: c.url}
+		err := conf.Cast()
+		if (err != nil) != c.err {
+			t.Errorf("%q: expected error %v, got %v", c.url, c.err, err)
+			continue
+		}
+		if err == nil && conf.Provider != c.provider {
+			t.Errorf("%q: expected provider %s, got %s", c.url, c.provider, conf.Provider)
+		}
+		if conf.EndpointURL != c.url {
+			t.Errorf("%q: EndpointURL changed to %q", c.url, conf.EndpointURL)
+		}
+	}
+}
+
+func TestCastRequired(t *testing.T) {
+	cases := []struct {
+		name string
+		conf Conf
+		err  bool
+	}{
+		{"bucket only", Conf{Bucket: "b"}, false},
+		{"no bucket", Conf{}, true},
+		{"full credentials", Conf{Bucket: "b", Credentials: Credentials{AccessKeyID: "id", SecretAccessKey: "secret"}}, false},
+		{"no secret", Conf{Bucket: "b", Credentials: Credentials{AccessKeyID: "id"}}, true},
+		{"no key id", Conf{Bucket: "b", Credentials: Credentials{SecretAccessKey: "secret"}}, true},
+		{"AWS storage class", Conf{Bucket: "b", StorageClass: "STANDARD_IA"}, false},
+		{"AWS glacier IR", Conf{Bucket: "b", StorageClass: "GLACIER_IR"}, false},
+		{"unknown AWS storage class", Conf{Bucket: "b", StorageClass: "NEARLINE"}, true},
+		{"S3-compatible storage class", Conf{Bucket: "b", EndpointURL: "https://storage.example.com", StorageClass: "NEARLINE"}, false},
+	}
+	for _, c := range cases {
+		err := c.conf.Cast()
+		if (err != nil) != c.err {
+			t.Errorf("%s: expected error %v, got %v", c.name, c.err, err)
+		}
+	}
+
+	c := Conf{Bucket: "b"}
+	if err := c.Cast(); err != nil {
+		t.Fatal(err)
+	}
+	if c.Region != defaultS3Region || c.StorageClass == "" || c.MaxUploadParts <= 0 {
+		t.Errorf("defaults aren't set: %+v", c)
+	}
+}